@@ -0,0 +1,77 @@
+// Пакет apierror формирует ответы об ошибках в формате RFC 7807
+// (application/problem+json) и присваивает каждому запросу идентификатор,
+// по которому ошибку клиента можно найти в журнале сервера.
+package apierror
+
+import "github.com/gin-gonic/gin"
+
+// ContentType - тип содержимого ответов об ошибках
+const ContentType = "application/problem+json"
+
+// typeBase - префикс URI типа проблемы; за ним следует код ошибки
+const typeBase = "/problems/"
+
+// Problem - тело ответа об ошибке по RFC 7807.
+// Типы с дополнительными полями встраивают Problem (см. Body).
+type Problem struct {
+	Type      string `json:"type" example:"/problems/project_not_found"`
+	Title     string `json:"title" example:"Project not found"`
+	Status    int    `json:"status" example:"404"`
+	Detail    string `json:"detail,omitempty" example:"project 42 does not exist"`
+	Instance  string `json:"instance" example:"/projects/42"`
+	Code      Code   `json:"code" example:"project_not_found"`
+	RequestID string `json:"request_id" example:"8f14e45fceea167a5a36dedd4bea2543"`
+}
+
+// Body - Problem или тип, встраивающий его ради дополнительных полей
+type Body interface {
+	problem() *Problem
+}
+
+func (p *Problem) problem() *Problem { return p }
+
+// New создает Problem для кода code. Статус и заголовок определяются кодом,
+// detail - пояснение к конкретному случаю (может быть пустым).
+func New(code Code, detail string) Problem {
+	def, ok := definitions[code]
+	if !ok {
+		def = definitions[CodeInternal]
+	}
+	return Problem{
+		Type:   typeBase + string(code),
+		Title:  def.title,
+		Status: def.status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// Write отправляет body как application/problem+json, дополнив его адресом
+// запроса и идентификатором запроса
+func Write(c *gin.Context, body Body) {
+	p := body.problem()
+	p.Instance = c.Request.URL.Path
+	p.RequestID = RequestIDFrom(c)
+	c.Header("Content-Type", ContentType)
+	c.JSON(p.Status, body)
+}
+
+// Respond отправляет ответ с кодом code и пояснением detail
+func Respond(c *gin.Context, code Code, detail string) {
+	p := New(code, detail)
+	Write(c, &p)
+}
+
+// Abort делает то же, что Respond, и прерывает цепочку обработчиков (для middleware)
+func Abort(c *gin.Context, code Code, detail string) {
+	Respond(c, code, detail)
+	c.Abort()
+}
+
+// Recovery - замена gin.Recovery(): паника в обработчике превращается в 500
+// в формате problem+json, а подробности остаются только в журнале
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recovered any) {
+		Abort(c, CodeInternal, "")
+	})
+}
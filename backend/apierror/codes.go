@@ -0,0 +1,91 @@
+package apierror
+
+import "net/http"
+
+// Code - стабильный машиночитаемый код ошибки. В отличие от title и detail,
+// коды не меняются между версиями API, и клиенты могут на них опираться.
+type Code string
+
+// Общие коды
+const (
+	CodeMalformedRequest     Code = "malformed_request"      // тело запроса - некорректный JSON
+	CodeUnsupportedMediaType Code = "unsupported_media_type" // тело запроса в неподдерживаемом формате
+	CodeInvalidParameter     Code = "invalid_parameter"      // неверный параметр пути или query-строки
+	CodeValidationFailed     Code = "validation_failed"      // поля тела запроса не прошли проверку
+	CodeUnauthorized         Code = "unauthorized"           // нет действительного access-токена
+	CodeForbidden            Code = "forbidden"              // не хватает роли в проекте
+	CodePreconditionFailed   Code = "precondition_failed"    // запись изменилась после получения ETag из If-Match
+	CodePreconditionRequired Code = "precondition_required"  // изменение без If-Match, когда он обязателен
+	CodeInternal             Code = "internal_error"         // внутренняя ошибка сервера
+)
+
+// Коды проектов и вакансий
+const (
+	CodeProjectNotFound         Code = "project_not_found"
+	CodeProjectHasVacancies     Code = "project_has_vacancies"
+	CodeVacancyNotFound         Code = "vacancy_not_found"
+	CodeInvalidStatusTransition Code = "invalid_status_transition"
+	CodeNoOpenings              Code = "no_openings"
+	CodeVacancyNotOpen          Code = "vacancy_not_open" // нанять можно только на вакансию в статусе open
+	CodeDeadlinePassed          Code = "deadline_passed"
+	CodeProjectDeleted          Code = "project_deleted" // вакансию нельзя восстановить, пока ее проект в корзине
+	CodeRevisionNotFound        Code = "revision_not_found"
+	CodeTeamMemberNotFound      Code = "team_member_not_found"
+)
+
+// Коды откликов
+const (
+	CodeApplicationNotFound Code = "application_not_found"
+	CodeVacancyNotAccepting Code = "vacancy_not_accepting_applications" // вакансия не в статусе open
+	CodeAlreadyApplied      Code = "already_applied"                    // с этого email уже откликались
+)
+
+// Коды пользователей и участников проектов
+const (
+	CodeInvalidCredentials  Code = "invalid_credentials"
+	CodeInvalidRefreshToken Code = "invalid_refresh_token" // неизвестный, истекший, отозванный или повторно предъявленный
+	CodeEmailTaken          Code = "email_taken"
+	CodeUserNotFound        Code = "user_not_found"
+	CodeMemberNotFound      Code = "member_not_found"
+	CodeLastOwner           Code = "last_owner" // у проекта должен остаться хотя бы один владелец
+)
+
+// definition - HTTP-статус и заголовок, общие для всех ошибок с одним кодом
+type definition struct {
+	status int
+	title  string
+}
+
+var definitions = map[Code]definition{
+	CodeMalformedRequest:     {http.StatusBadRequest, "Malformed request body"},
+	CodeUnsupportedMediaType: {http.StatusUnsupportedMediaType, "Unsupported media type"},
+	CodeInvalidParameter:     {http.StatusBadRequest, "Invalid parameter"},
+	CodeValidationFailed:     {http.StatusUnprocessableEntity, "Validation failed"},
+	CodeUnauthorized:         {http.StatusUnauthorized, "Authentication required"},
+	CodeForbidden:            {http.StatusForbidden, "Forbidden"},
+	CodePreconditionFailed:   {http.StatusPreconditionFailed, "Precondition failed"},
+	CodePreconditionRequired: {http.StatusPreconditionRequired, "Precondition required"},
+	CodeInternal:             {http.StatusInternalServerError, "Internal server error"},
+
+	CodeProjectNotFound:         {http.StatusNotFound, "Project not found"},
+	CodeProjectHasVacancies:     {http.StatusConflict, "Project has vacancies"},
+	CodeVacancyNotFound:         {http.StatusNotFound, "Vacancy not found"},
+	CodeInvalidStatusTransition: {http.StatusConflict, "Status transition not allowed"},
+	CodeNoOpenings:              {http.StatusConflict, "No openings left"},
+	CodeVacancyNotOpen:          {http.StatusConflict, "Vacancy is not open"},
+	CodeDeadlinePassed:          {http.StatusConflict, "Project deadline has passed"},
+	CodeProjectDeleted:          {http.StatusConflict, "Project is in the trash"},
+	CodeRevisionNotFound:        {http.StatusNotFound, "Revision not found"},
+	CodeTeamMemberNotFound:      {http.StatusNotFound, "Team member not found"},
+
+	CodeApplicationNotFound: {http.StatusNotFound, "Application not found"},
+	CodeVacancyNotAccepting: {http.StatusConflict, "Vacancy is not accepting applications"},
+	CodeAlreadyApplied:      {http.StatusConflict, "Already applied"},
+
+	CodeInvalidCredentials:  {http.StatusUnauthorized, "Invalid email or password"},
+	CodeInvalidRefreshToken: {http.StatusUnauthorized, "Invalid refresh token"},
+	CodeEmailTaken:          {http.StatusConflict, "Email is already registered"},
+	CodeUserNotFound:        {http.StatusNotFound, "User not found"},
+	CodeMemberNotFound:      {http.StatusNotFound, "Member not found"},
+	CodeLastOwner:           {http.StatusConflict, "Project must keep an owner"},
+}
@@ -0,0 +1,48 @@
+package apierror
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader - заголовок с идентификатором запроса
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey - ключ, под которым идентификатор хранится в gin.Context
+const requestIDKey = "apierror.request_id"
+
+// validRequestID ограничивает идентификаторы, принятые от клиента или прокси:
+// они попадают в журнал и в ответ, поэтому пропускаем только безопасные символы
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// RequestID присваивает запросу идентификатор: берет его из заголовка X-Request-ID,
+// если он там есть, иначе генерирует новый. Идентификатор возвращается в том же
+// заголовке ответа и попадает в тело ошибок (см. Write).
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// RequestIDFrom возвращает идентификатор текущего запроса или пустую строку,
+// если middleware RequestID не подключен
+func RequestIDFrom(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unavailable" // запрос можно обработать и без идентификатора
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// MinHMACSecretLength - минимальная длина секрета для HS256 (256 бит)
+const MinHMACSecretLength = 32
+
+// KeySet - ключи подписи и проверки access-токенов. Интерфейс позволяет подменить
+// источник ключей: локальный IdP в тестах, ротация секретов, ключи внешнего провайдера.
+type KeySet interface {
+	// SigningKey возвращает идентификатор (kid), алгоритм и ключ для подписи новых токенов
+	SigningKey() (kid string, method jwt.SigningMethod, key any, err error)
+	// VerificationKey возвращает алгоритм и ключ для проверки токена с данным kid
+	VerificationKey(kid string) (method jwt.SigningMethod, key any, err error)
+}
+
+// HMACKeySet подписывает токены HS256 текущим секретом и принимает токены,
+// подписанные любым из предыдущих секретов (для плавной смены секрета)
+type HMACKeySet struct {
+	currentKID string
+	keys       map[string][]byte
+}
+
+// NewHMACKeySet создает набор ключей из текущего и предыдущих секретов
+func NewHMACKeySet(current string, previous ...string) (*HMACKeySet, error) {
+	set := &HMACKeySet{keys: make(map[string][]byte)}
+	for i, secret := range append([]string{current}, previous...) {
+		if len(secret) < MinHMACSecretLength {
+			return nil, fmt.Errorf("JWT secret must be at least %d bytes long", MinHMACSecretLength)
+		}
+		kid := hmacKeyID(secret)
+		set.keys[kid] = []byte(secret)
+		if i == 0 {
+			set.currentKID = kid
+		}
+	}
+	return set, nil
+}
+
+// hmacKeyID - короткий идентификатор секрета, по которому нельзя восстановить сам секрет
+func hmacKeyID(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:8])
+}
+
+// SigningKey реализует KeySet
+func (s *HMACKeySet) SigningKey() (string, jwt.SigningMethod, any, error) {
+	return s.currentKID, jwt.SigningMethodHS256, s.keys[s.currentKID], nil
+}
+
+// VerificationKey реализует KeySet
+func (s *HMACKeySet) VerificationKey(kid string) (jwt.SigningMethod, any, error) {
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return jwt.SigningMethodHS256, key, nil
+}
+
+// Claims - содержимое access-токена. Subject - ID пользователя.
+type Claims struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+	jwt.RegisteredClaims
+}
+
+// ErrInvalidToken - токен не прошел проверку (подпись, срок, издатель, аудитория)
+var ErrInvalidToken = errors.New("invalid access token")
+
+// TokenService выпускает и проверяет access-токены (JWT)
+type TokenService struct {
+	Keys      KeySet
+	Issuer    string
+	Audience  string
+	AccessTTL time.Duration
+}
+
+// IssueAccessToken подписывает access-токен для пользователя
+func (t *TokenService) IssueAccessToken(user db.User) (string, time.Time, error) {
+	kid, method, key, err := t.Keys.SigningKey()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(t.AccessTTL)
+	claims := Claims{
+		Email: user.Email,
+		Name:  user.Name,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    t.Issuer,
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
+			Audience:  jwt.ClaimStrings{t.Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	return signed, expiresAt, err
+}
+
+// ParseAccessToken проверяет подпись, срок действия, издателя и аудиторию токена
+func (t *TokenService) ParseAccessToken(raw string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		method, key, err := t.Keys.VerificationKey(kid)
+		if err != nil {
+			return nil, err
+		}
+		// Не даем токену выбрать алгоритм самому (защита от подмены alg)
+		if token.Method.Alg() != method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+		}
+		return key, nil
+	},
+		jwt.WithIssuer(t.Issuer),
+		jwt.WithAudience(t.Audience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return claims, nil
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/troodinc/trood-front-hackathon/apierror"
+)
+
+// principalKey - ключ, под которым текущий пользователь хранится в gin.Context
+const principalKey = "auth.principal"
+
+// Principal - пользователь, от имени которого выполняется запрос.
+// Данные берутся из access-токена, без обращения к базе.
+type Principal struct {
+	UserID uint
+	Email  string
+	Name   string
+}
+
+// BearerToken достает токен из заголовка Authorization: Bearer <token>
+func BearerToken(c *gin.Context) string {
+	scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// RequireAuth пропускает запрос только с действительным access-токеном
+// и кладет пользователя в контекст (см. CurrentUser). Иначе отвечает 401.
+func RequireAuth(tokens *TokenService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw := BearerToken(c)
+		if raw == "" {
+			unauthorized(c, "Authorization token required")
+			return
+		}
+
+		claims, err := tokens.ParseAccessToken(raw)
+		if err != nil {
+			c.Error(err)
+			unauthorized(c, "Invalid or expired token")
+			return
+		}
+		userID, err := strconv.ParseUint(claims.Subject, 10, 64)
+		if err != nil {
+			unauthorized(c, "Invalid or expired token")
+			return
+		}
+
+		c.Set(principalKey, Principal{UserID: uint(userID), Email: claims.Email, Name: claims.Name})
+		c.Next()
+	}
+}
+
+// unauthorized прерывает запрос с 401 и подсказкой схемы аутентификации
+func unauthorized(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", `Bearer realm="trood"`)
+	apierror.Abort(c, apierror.CodeUnauthorized, message)
+}
+
+// CurrentUser возвращает пользователя, которого положил в контекст RequireAuth
+func CurrentUser(c *gin.Context) (Principal, bool) {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return Principal{}, false
+	}
+	principal, ok := value.(Principal)
+	return principal, ok
+}
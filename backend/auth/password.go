@@ -0,0 +1,64 @@
+// Package auth содержит криптографические примитивы аутентификации:
+// хеширование паролей и выпуск токенов сессий.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Ограничения на пароль. bcrypt учитывает только первые 72 байта,
+// поэтому более длинные пароли отклоняем, а не обрезаем молча.
+const (
+	MinPasswordLength = 8
+	MaxPasswordBytes  = 72
+)
+
+// ErrPasswordTooLong - пароль длиннее MaxPasswordBytes байт
+var ErrPasswordTooLong = errors.New("password must be at most 72 bytes")
+
+// dummyHash используется, когда пользователь не найден: сравнение с ним
+// занимает столько же времени, сколько настоящая проверка, и не выдает
+// по времени ответа, зарегистрирован ли email
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+// HashPassword возвращает bcrypt-хеш пароля
+func HashPassword(password string) (string, error) {
+	if len(password) > MaxPasswordBytes {
+		return "", ErrPasswordTooLong
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), err
+}
+
+// CheckPassword сравнивает пароль с хешем. Пустой хеш (пользователь не найден)
+// проверяется против dummyHash и всегда дает false.
+func CheckPassword(hash, password string) bool {
+	if hash == "" {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// NewToken возвращает случайный токен для клиента и его хеш для хранения в базе
+func NewToken() (token, hash string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashToken(token), nil
+}
+
+// HashToken возвращает sha256 токена в hex. Токены случайные и длинные,
+// поэтому медленный хеш вроде bcrypt для них не нужен.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
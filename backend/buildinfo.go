@@ -0,0 +1,32 @@
+package main
+
+import (
+	"runtime/debug"
+
+	"github.com/troodinc/trood-front-hackathon/handlers"
+)
+
+// Версия и коммит сборки. Задаются при компиляции:
+//
+//	go build -ldflags "-X main.version=1.2.0 -X main.commit=$(git rev-parse --short HEAD)"
+var (
+	version = "dev"
+	commit  = ""
+)
+
+// buildInfo возвращает версию сборки. Если коммит не передан через -ldflags,
+// берем его из VCS-информации, которую go build встраивает сам.
+func buildInfo() handlers.BuildInfo {
+	info := handlers.BuildInfo{Version: version, Commit: commit}
+	if info.Commit == "" {
+		info.Commit = "unknown"
+		if bi, ok := debug.ReadBuildInfo(); ok {
+			for _, setting := range bi.Settings {
+				if setting.Key == "vcs.revision" {
+					info.Commit = setting.Value
+				}
+			}
+		}
+	}
+	return info
+}
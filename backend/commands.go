@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/troodinc/trood-front-hackathon/config"
+	db "github.com/troodinc/trood-front-hackathon/database"
+	"github.com/troodinc/trood-front-hackathon/repository"
+)
+
+// runCommand выполняет служебную CLI-команду, если она передана в аргументах.
+// Возвращает false, если аргументов нет и нужно запускать HTTP-сервер.
+//
+// Флаги конфигурации указываются перед командой: main -config prod.yaml migrate up
+//
+// Использование:
+//
+//	main migrate up          применить все непримененные миграции
+//	main migrate down [n]    откатить n последних миграций (по умолчанию 1)
+//	main migrate status      показать состояние миграций
+//	main repair orphans      найти вакансии, ссылающиеся на удаленные проекты
+//	main repair orphans --delete   найти и удалить такие вакансии
+//	main projects set-owner <project_id> <email>   назначить пользователя владельцем проекта
+func runCommand(cfg config.Config, args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	switch args[0] {
+	case "migrate":
+		runMigrate(cfg, args[1:])
+	case "repair":
+		runRepair(cfg, args[1:])
+	case "projects":
+		runProjects(cfg, args[1:])
+	default:
+		log.Fatalf("Unknown command %q. Available commands: migrate, repair, projects", args[0])
+	}
+	return true
+}
+
+func runMigrate(cfg config.Config, args []string) {
+	if len(args) == 0 {
+		log.Fatal("Usage: migrate up|down [n]|status")
+	}
+
+	db.OpenDatabase(cfg.Database)
+	defer db.CloseDatabase()
+
+	switch args[0] {
+	case "up":
+		applied, err := db.MigrateUp()
+		if err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		log.Printf("%d migration(s) applied", len(applied))
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				log.Fatalf("Invalid number of steps %q", args[1])
+			}
+			steps = n
+		}
+		reverted, err := db.MigrateDown(steps)
+		if err != nil {
+			log.Fatalf("Rollback failed: %v", err)
+		}
+		log.Printf("%d migration(s) reverted", len(reverted))
+
+	case "status":
+		statuses, err := db.MigrationStatuses()
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, s := range statuses {
+			state, appliedAt := "pending", "-"
+			if s.Applied {
+				state = "applied"
+				if !s.ChecksumMatches {
+					state = "applied (checksum mismatch)"
+				}
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+		}
+		w.Flush()
+
+	default:
+		log.Fatalf("Unknown migrate subcommand %q. Usage: migrate up|down [n]|status", args[0])
+	}
+}
+
+func runRepair(cfg config.Config, args []string) {
+	if len(args) == 0 || args[0] != "orphans" {
+		log.Fatal("Usage: repair orphans [--delete]")
+	}
+	fix := len(args) > 1 && args[1] == "--delete"
+
+	db.InitDatabase(cfg.Database)
+	defer db.CloseDatabase()
+
+	orphans, err := db.FindOrphanedVacancies()
+	if err != nil {
+		log.Fatalf("Failed to look for orphaned vacancies: %v", err)
+	}
+	if len(orphans) == 0 {
+		log.Println("No orphaned vacancies found")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VACANCY ID\tMISSING PROJECT ID\tNAME")
+	for _, v := range orphans {
+		fmt.Fprintf(w, "%d\t%d\t%s\n", v.ID, v.ProjectID, v.Name)
+	}
+	w.Flush()
+
+	if !fix {
+		log.Printf("Found %d orphaned vacancies. Run with --delete to remove them.", len(orphans))
+		return
+	}
+
+	deleted, err := db.DeleteOrphanedVacancies()
+	if err != nil {
+		log.Fatalf("Failed to delete orphaned vacancies: %v", err)
+	}
+	log.Printf("Deleted %d orphaned vacancies", deleted)
+}
+
+// runProjects назначает владельца проекту. Нужна прежде всего для проектов,
+// созданных до появления учетных записей: у них нет владельца, и через API
+// его назначить некому.
+func runProjects(cfg config.Config, args []string) {
+	if len(args) != 3 || args[0] != "set-owner" {
+		log.Fatal("Usage: projects set-owner <project_id> <email>")
+	}
+	projectID, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid project ID %q", args[1])
+	}
+
+	db.InitDatabase(cfg.Database)
+	defer db.CloseDatabase()
+	store := repository.NewSQLStore(db.DB)
+	ctx := context.Background()
+
+	user, err := store.GetUserByEmail(ctx, strings.ToLower(strings.TrimSpace(args[2])))
+	if err != nil {
+		log.Fatalf("Failed to find user %q: %v", args[2], err)
+	}
+	if err := store.SetProjectMember(ctx, uint(projectID), user.ID, db.RoleOwner); err != nil {
+		log.Fatalf("Failed to set project owner: %v", err)
+	}
+	log.Printf("%s is now an owner of project %d", user.Email, projectID)
+}
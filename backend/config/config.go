@@ -0,0 +1,420 @@
+// Package config собирает настройки сервера из файла YAML, переменных окружения
+// и флагов командной строки. Приоритет (от низшего к высшему):
+// значения по умолчанию -> файл -> переменные окружения -> флаги.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/troodinc/trood-front-hackathon/auth"
+	db "github.com/troodinc/trood-front-hackathon/database"
+	"github.com/troodinc/trood-front-hackathon/repository"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFile - файл конфигурации, который читается, если он есть в рабочем каталоге
+const DefaultFile = "config.yaml"
+
+// Config - все настройки приложения
+type Config struct {
+	Server    ServerConfig    `yaml:"server"`
+	Database  db.Config       `yaml:"database"`
+	CORS      CORSConfig      `yaml:"cors"`
+	Log       LogConfig       `yaml:"log"`
+	Seed      SeedConfig      `yaml:"seed"`
+	Projects  ProjectsConfig  `yaml:"projects"`
+	Vacancies VacanciesConfig `yaml:"vacancies"`
+	Trash     TrashConfig     `yaml:"trash"`
+	Auth      AuthConfig      `yaml:"auth"`
+}
+
+// ServerConfig - параметры HTTP-сервера
+type ServerConfig struct {
+	Host string `yaml:"host"` // пустая строка - все интерфейсы
+	Port int    `yaml:"port"`
+	// ShutdownTimeout - сколько ждать завершения активных запросов при остановке
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// ShutdownDelay - пауза между переходом /readyz в 503 и закрытием слушателя
+	ShutdownDelay time.Duration `yaml:"shutdown_delay"`
+	// RequireIfMatch - отклонять PUT, PATCH и DELETE проектов и вакансий без If-Match
+	RequireIfMatch bool `yaml:"require_if_match"`
+}
+
+// Addr возвращает адрес для net/http в виде host:port
+func (s ServerConfig) Addr() string {
+	return s.Host + ":" + strconv.Itoa(s.Port)
+}
+
+// CORSConfig - разрешенные источники запросов браузера
+type CORSConfig struct {
+	AllowOrigins []string      `yaml:"allow_origins"` // "*" разрешает любой источник
+	MaxAge       time.Duration `yaml:"max_age"`       // время кэширования preflight-запроса
+}
+
+// AllowsAll сообщает, разрешены ли запросы с любого источника
+func (c CORSConfig) AllowsAll() bool {
+	for _, origin := range c.AllowOrigins {
+		if origin == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// LogConfig - уровень и формат логов
+type LogConfig struct {
+	Level  string `yaml:"level"`  // debug, info, warn, error
+	Format string `yaml:"format"` // text или json
+}
+
+// SlogLevel переводит уровень из конфигурации в slog.Level
+func (l LogConfig) SlogLevel() slog.Level {
+	switch l.Level {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// SeedConfig - заполнение пустой базы демонстрационными проектами
+type SeedConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// ProjectsConfig - поведение API проектов
+type ProjectsConfig struct {
+	// DeletePolicy - что делать с вакансиями при удалении проекта: cascade, restrict или archive
+	DeletePolicy string `yaml:"delete_policy"`
+}
+
+// VacanciesConfig - поведение вакансий
+type VacanciesConfig struct {
+	// ExpiryInterval - как часто переводить в expired вакансии проектов с прошедшим дедлайном; 0 отключает
+	ExpiryInterval time.Duration `yaml:"expiry_interval"`
+}
+
+// TrashConfig - корзина удаленных проектов и вакансий
+type TrashConfig struct {
+	// RetentionDays - через сколько дней удаленные записи удаляются окончательно; 0 - хранить всегда
+	RetentionDays int `yaml:"retention_days"`
+	// PurgeInterval - как часто искать записи, срок хранения которых истек
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+}
+
+// Retention возвращает срок хранения удаленных записей; 0 - хранить всегда
+func (t TrashConfig) Retention() time.Duration {
+	return time.Duration(t.RetentionDays) * 24 * time.Hour
+}
+
+// AuthConfig - параметры аутентификации
+type AuthConfig struct {
+	// AccessTTL - срок жизни access-токена (JWT)
+	AccessTTL time.Duration `yaml:"access_ttl"`
+	// RefreshTTL - срок жизни refresh-токена
+	RefreshTTL time.Duration `yaml:"refresh_ttl"`
+	// Issuer и Audience записываются в токен и проверяются при каждом запросе
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+	// JWTSecret подписывает токены (HS256, не короче 32 байт). Если не задан,
+	// при старте генерируется случайный и токены не переживают перезапуск.
+	JWTSecret string `yaml:"jwt_secret"`
+	// JWTPreviousSecrets - прежние секреты, токены с которыми еще принимаются
+	JWTPreviousSecrets []string `yaml:"jwt_previous_secrets"`
+}
+
+// Default возвращает настройки, с которыми сервер работал до появления конфигурации
+func Default() Config {
+	return Config{
+		Server:   ServerConfig{Port: 8080, ShutdownTimeout: 10 * time.Second},
+		Database: db.Config{Driver: db.DriverSQLite, DSN: db.DefaultSQLiteDSN},
+		CORS: CORSConfig{
+			AllowOrigins: []string{"http://localhost:5173", "http://65.108.87.81:5173"},
+			MaxAge:       12 * time.Hour,
+		},
+		Log:       LogConfig{Level: "info", Format: "text"},
+		Seed:      SeedConfig{Enabled: true},
+		Projects:  ProjectsConfig{DeletePolicy: string(repository.DeletePolicyCascade)},
+		Vacancies: VacanciesConfig{ExpiryInterval: time.Hour},
+		Trash:     TrashConfig{RetentionDays: 30, PurgeInterval: time.Hour},
+		Auth: AuthConfig{
+			AccessTTL:  15 * time.Minute,
+			RefreshTTL: 7 * 24 * time.Hour,
+			Issuer:     "trood-backend",
+			Audience:   "trood-api",
+		},
+	}
+}
+
+// Load собирает конфигурацию из файла, окружения и флагов.
+// args - аргументы командной строки без имени программы; возвращаются
+// оставшиеся после флагов аргументы (например, "migrate up").
+//
+// Файл берется из флага -config, переменной CONFIG_FILE или config.yaml
+// в рабочем каталоге (если он существует).
+func Load(args []string) (Config, []string, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("trood-backend", flag.ContinueOnError)
+	configFile := fs.String("config", "", "path to the YAML config file (default: $CONFIG_FILE or ./config.yaml)")
+	port := fs.Int("port", 0, "HTTP port to listen on")
+	shutdownTimeout := fs.Duration("shutdown-timeout", 0, "how long to wait for in-flight requests on shutdown")
+	dbDriver := fs.String("db-driver", "", "database driver: sqlite3 or postgres")
+	dbDSN := fs.String("db-dsn", "", "database DSN: SQLite file or PostgreSQL URL")
+	corsOrigins := fs.String("cors-origins", "", "comma-separated list of allowed CORS origins")
+	logLevel := fs.String("log-level", "", "log level: debug, info, warn, error")
+	logFormat := fs.String("log-format", "", "log format: text or json")
+	seed := fs.Bool("seed", false, "seed an empty database with sample projects")
+	deletePolicy := fs.String("delete-policy", "", "project delete policy: cascade, restrict or archive")
+	if err := fs.Parse(args); err != nil {
+		return cfg, nil, err
+	}
+
+	// 1. Файл
+	path, required := *configFile, true
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path == "" {
+		path, required = DefaultFile, false
+	}
+	if err := loadFile(&cfg, path, required); err != nil {
+		return cfg, nil, err
+	}
+
+	// 2. Переменные окружения
+	if err := applyEnv(&cfg); err != nil {
+		return cfg, nil, err
+	}
+
+	// 3. Флаги - только те, что явно переданы
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "port":
+			cfg.Server.Port = *port
+		case "shutdown-timeout":
+			cfg.Server.ShutdownTimeout = *shutdownTimeout
+		case "db-driver":
+			cfg.Database.Driver = *dbDriver
+		case "db-dsn":
+			cfg.Database.DSN = *dbDSN
+		case "cors-origins":
+			cfg.CORS.AllowOrigins = splitList(*corsOrigins)
+		case "log-level":
+			cfg.Log.Level = *logLevel
+		case "log-format":
+			cfg.Log.Format = *logFormat
+		case "seed":
+			cfg.Seed.Enabled = *seed
+		case "delete-policy":
+			cfg.Projects.DeletePolicy = *deletePolicy
+		}
+	})
+
+	if err := cfg.Validate(); err != nil {
+		return cfg, nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return cfg, fs.Args(), nil
+}
+
+// loadFile накладывает значения из YAML-файла поверх cfg.
+// Неизвестные ключи считаются ошибкой, чтобы опечатки не проходили молча.
+func loadFile(cfg *Config, path string, required bool) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !required {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv накладывает переменные окружения поверх cfg
+func applyEnv(cfg *Config) error {
+	if value, ok := os.LookupEnv("PORT"); ok {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("PORT: %q is not a number", value)
+		}
+		cfg.Server.Port = port
+	}
+	setString(&cfg.Server.Host, "HOST")
+	if err := setDuration(&cfg.Server.ShutdownTimeout, "SHUTDOWN_TIMEOUT"); err != nil {
+		return err
+	}
+	if err := setDuration(&cfg.Server.ShutdownDelay, "SHUTDOWN_DELAY"); err != nil {
+		return err
+	}
+	if value, ok := os.LookupEnv("REQUIRE_IF_MATCH"); ok {
+		required, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("REQUIRE_IF_MATCH: %q is not a boolean", value)
+		}
+		cfg.Server.RequireIfMatch = required
+	}
+	setString(&cfg.Database.Driver, "DB_DRIVER")
+	setString(&cfg.Database.DSN, "DB_DSN")
+	if value, ok := os.LookupEnv("CORS_ALLOW_ORIGINS"); ok {
+		cfg.CORS.AllowOrigins = splitList(value)
+	}
+	if err := setDuration(&cfg.CORS.MaxAge, "CORS_MAX_AGE"); err != nil {
+		return err
+	}
+	setString(&cfg.Log.Level, "LOG_LEVEL")
+	setString(&cfg.Log.Format, "LOG_FORMAT")
+	if value, ok := os.LookupEnv("SEED_ENABLED"); ok {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("SEED_ENABLED: %q is not a boolean", value)
+		}
+		cfg.Seed.Enabled = enabled
+	}
+	setString(&cfg.Projects.DeletePolicy, "PROJECT_DELETE_POLICY")
+	if err := setDuration(&cfg.Vacancies.ExpiryInterval, "VACANCY_EXPIRY_INTERVAL"); err != nil {
+		return err
+	}
+	if value, ok := os.LookupEnv("TRASH_RETENTION_DAYS"); ok {
+		days, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("TRASH_RETENTION_DAYS: %q is not a number", value)
+		}
+		cfg.Trash.RetentionDays = days
+	}
+	if err := setDuration(&cfg.Trash.PurgeInterval, "TRASH_PURGE_INTERVAL"); err != nil {
+		return err
+	}
+	if err := setDuration(&cfg.Auth.AccessTTL, "AUTH_ACCESS_TTL"); err != nil {
+		return err
+	}
+	if err := setDuration(&cfg.Auth.RefreshTTL, "AUTH_REFRESH_TTL"); err != nil {
+		return err
+	}
+	setString(&cfg.Auth.Issuer, "JWT_ISSUER")
+	setString(&cfg.Auth.Audience, "JWT_AUDIENCE")
+	setString(&cfg.Auth.JWTSecret, "JWT_SECRET")
+	if value, ok := os.LookupEnv("JWT_PREVIOUS_SECRETS"); ok {
+		cfg.Auth.JWTPreviousSecrets = splitList(value)
+	}
+	return nil
+}
+
+// setString заменяет значение, если переменная окружения задана и не пуста
+func setString(target *string, name string) {
+	if value := os.Getenv(name); value != "" {
+		*target = value
+	}
+}
+
+// setDuration заменяет значение длительностью из переменной окружения (например, 30s)
+func setDuration(target *time.Duration, name string) error {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	*target = duration
+	return nil
+}
+
+// splitList разбивает список через запятую, пропуская пустые элементы
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Validate проверяет согласованность настроек
+func (c Config) Validate() error {
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		return fmt.Errorf("server.port must be between 1 and 65535, got %d", c.Server.Port)
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		return errors.New("server.shutdown_timeout must be positive")
+	}
+	if c.Server.ShutdownDelay < 0 {
+		return errors.New("server.shutdown_delay must not be negative")
+	}
+	if err := c.Database.Validate(); err != nil {
+		return fmt.Errorf("database: %w", err)
+	}
+	if len(c.CORS.AllowOrigins) == 0 {
+		return errors.New("cors.allow_origins must list at least one origin (use \"*\" to allow any)")
+	}
+	for _, origin := range c.CORS.AllowOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("cors.allow_origins: %q must look like http(s)://host[:port]", origin)
+		}
+	}
+	if c.CORS.MaxAge < 0 {
+		return errors.New("cors.max_age must not be negative")
+	}
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("log.level must be one of debug, info, warn, error, got %q", c.Log.Level)
+	}
+	switch c.Log.Format {
+	case "text", "json":
+	default:
+		return fmt.Errorf("log.format must be text or json, got %q", c.Log.Format)
+	}
+	if _, err := repository.ParseDeletePolicy(c.Projects.DeletePolicy); err != nil {
+		return fmt.Errorf("projects.delete_policy: %w", err)
+	}
+	if c.Vacancies.ExpiryInterval < 0 {
+		return errors.New("vacancies.expiry_interval must not be negative")
+	}
+	if c.Trash.RetentionDays < 0 {
+		return errors.New("trash.retention_days must not be negative")
+	}
+	if c.Trash.RetentionDays > 0 && c.Trash.PurgeInterval <= 0 {
+		return errors.New("trash.purge_interval must be positive when trash.retention_days is set")
+	}
+	if c.Auth.AccessTTL <= 0 || c.Auth.RefreshTTL <= 0 {
+		return errors.New("auth.access_ttl and auth.refresh_ttl must be positive")
+	}
+	if c.Auth.Issuer == "" || c.Auth.Audience == "" {
+		return errors.New("auth.issuer and auth.audience must not be empty")
+	}
+	if c.Auth.JWTSecret == "" && len(c.Auth.JWTPreviousSecrets) > 0 {
+		return errors.New("auth.jwt_previous_secrets requires auth.jwt_secret")
+	}
+	for _, secret := range append([]string{c.Auth.JWTSecret}, c.Auth.JWTPreviousSecrets...) {
+		if secret != "" && len(secret) < auth.MinHMACSecretLength {
+			return fmt.Errorf("JWT secrets must be at least %d bytes long", auth.MinHMACSecretLength)
+		}
+	}
+	return nil
+}
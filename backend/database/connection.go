@@ -1,23 +1,70 @@
 package database
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
 )
 
 var DB *sqlx.DB
 
-func InitDatabase() {
+// Поддерживаемые драйверы базы данных
+const (
+	DriverSQLite   = "sqlite3"
+	DriverPostgres = "postgres"
+)
+
+// DefaultSQLiteDSN - файл базы по умолчанию.
+// _foreign_keys=on включает проверку внешних ключей для каждого соединения пула:
+// PRAGMA foreign_keys действует только на то соединение, в котором выполнен, а database/sql
+// открывает новые соединения сам, поэтому включать проверку нужно в DSN, а не одним запросом.
+const DefaultSQLiteDSN = "./data/myapp.db?_foreign_keys=on"
+
+// Config - параметры подключения к базе
+type Config struct {
+	Driver string `yaml:"driver"` // sqlite3 или postgres
+	DSN    string `yaml:"dsn"`    // путь к файлу для SQLite, строка подключения для PostgreSQL
+}
+
+// Validate проверяет, что драйвер известен и DSN задан
+func (c Config) Validate() error {
+	switch c.Driver {
+	case DriverSQLite, DriverPostgres:
+	default:
+		return fmt.Errorf("unsupported database driver %q (expected %s or %s)", c.Driver, DriverSQLite, DriverPostgres)
+	}
+	if c.DSN == "" {
+		return fmt.Errorf("database DSN is required for driver %s", c.Driver)
+	}
+	return nil
+}
+
+// OpenDatabase открывает соединение с базой без применения миграций.
+// Используется CLI-командами (например, migrate down), которым нельзя
+// автоматически накатывать схему.
+func OpenDatabase(cfg Config) {
 	var err error
 
-	if err := os.MkdirAll("./data", os.ModePerm); err != nil {
-		log.Fatalf("Error creating 'data' directory: %v", err)
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid database configuration: %v", err)
 	}
 
-	DB, err = sqlx.Open("sqlite3", "./data/myapp.db")
+	if cfg.Driver == DriverSQLite {
+		// Создаем каталог для файла базы (для ./data/myapp.db это ./data)
+		path, _, _ := strings.Cut(strings.TrimPrefix(cfg.DSN, "file:"), "?")
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			log.Fatalf("Error creating database directory: %v", err)
+		}
+	}
+
+	DB, err = sqlx.Open(cfg.Driver, cfg.DSN)
 	if err != nil {
 		log.Fatalf("Error opening database: %v", err)
 	}
@@ -26,32 +73,22 @@ func InitDatabase() {
 		log.Fatalf("Error pinging the database: %v", err)
 	}
 
-	schema := `
-	CREATE TABLE IF NOT EXISTS projects (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		name TEXT NOT NULL,
-		description TEXT,
-		deadline TEXT NOT NULL,
-		experience TEXT NOT NULL
-	);
-
-	CREATE TABLE IF NOT EXISTS vacancies (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		project_id INTEGER NOT NULL,
-		name TEXT NOT NULL,
-		description TEXT,
-		field TEXT,
-		country TEXT,
-		experience TEXT,
-		FOREIGN KEY (project_id) REFERENCES projects(id)
-	);`
-
-	_, err = DB.Exec(schema)
+	log.Printf("Connected to %s database", cfg.Driver)
+}
+
+// InitDatabase открывает соединение и применяет все непримененные миграции
+func InitDatabase(cfg Config) {
+	OpenDatabase(cfg)
+
+	applied, err := MigrateUp()
 	if err != nil {
-		log.Fatalf("Error creating tables: %v", err)
+		if cfg.Driver == DriverSQLite && strings.Contains(err.Error(), "fts5") {
+			log.Fatalf("Error applying migrations: %v (build the binary with -tags sqlite_fts5)", err)
+		}
+		log.Fatalf("Error applying migrations: %v", err)
 	}
 
-	log.Println("Database initialized with projects and vacancies tables")
+	log.Printf("Database initialized, %d new migration(s) applied", len(applied))
 }
 
 func CloseDatabase() {
@@ -60,3 +97,20 @@ func CloseDatabase() {
 	}
 	log.Println("Database connection closed")
 }
+
+// Ping проверяет, что база отвечает
+func Ping(ctx context.Context) error {
+	return DB.PingContext(ctx)
+}
+
+// Size возвращает размер базы в байтах: размер файла для SQLite,
+// pg_database_size для PostgreSQL
+func Size(ctx context.Context) (int64, error) {
+	query := "SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()"
+	if DB.DriverName() == DriverPostgres {
+		query = "SELECT pg_database_size(current_database())"
+	}
+	var size int64
+	err := DB.GetContext(ctx, &size, query)
+	return size, err
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// DateLayout - формат, в котором даты хранятся в базе и отдаются в JSON
+const DateLayout = "2006-01-02"
+
+// dateLayouts - форматы, принимаемые на входе. DD.MM.YYYY остался от начальных
+// данных, в которых дедлайны хранились именно так.
+var dateLayouts = []string{DateLayout, "02.01.2006"}
+
+// Date - календарная дата без времени (например, дедлайн проекта).
+// Нулевое значение означает отсутствие даты: в JSON это null, в базе - NULL.
+type Date struct {
+	time.Time
+}
+
+// NewDate создает дату из года, месяца и дня
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// Today возвращает текущую дату по UTC
+func Today() Date {
+	return DateOf(time.Now().UTC())
+}
+
+// DateOf отбрасывает время и часовой пояс, оставляя календарную дату
+func DateOf(t time.Time) Date {
+	return NewDate(t.Date())
+}
+
+// ParseDate разбирает дату в формате YYYY-MM-DD или DD.MM.YYYY
+func ParseDate(value string) (Date, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return DateOf(t), nil
+		}
+	}
+	return Date{}, fmt.Errorf("invalid date %q: expected YYYY-MM-DD or DD.MM.YYYY", value)
+}
+
+// String возвращает дату в формате YYYY-MM-DD или пустую строку для нулевой даты
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(DateLayout)
+}
+
+// MarshalJSON реализует json.Marshaler
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON реализует json.Unmarshaler
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = Date{}
+		return nil
+	}
+	// Ошибки возвращаем как *json.UnmarshalTypeError: encoding/json дополняет
+	// их именем поля, и обработчик может указать, какое поле некорректно
+	invalid := &json.UnmarshalTypeError{Value: "string " + string(data), Type: reflect.TypeOf(Date{})}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		invalid.Value = string(data)
+		return invalid
+	}
+	if value == "" {
+		*d = Date{}
+		return nil
+	}
+	parsed, err := ParseDate(value)
+	if err != nil {
+		return invalid
+	}
+	*d = parsed
+	return nil
+}
+
+// Scan реализует sql.Scanner. PostgreSQL отдает DATE как time.Time,
+// SQLite - как строку.
+func (d *Date) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*d = Date{}
+		return nil
+	case time.Time:
+		*d = DateOf(v)
+		return nil
+	case string:
+		return d.scanString(v)
+	case []byte:
+		return d.scanString(string(v))
+	default:
+		return fmt.Errorf("cannot scan %T into Date", src)
+	}
+}
+
+func (d *Date) scanString(value string) error {
+	parsed, err := ParseDate(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Value реализует driver.Valuer
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	return d.String(), nil
+}
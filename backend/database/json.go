@@ -0,0 +1,42 @@
+package database
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// JSON - готовый JSON-документ, который хранится в колонке как есть
+// (TEXT в SQLite, JSONB в PostgreSQL). Пустое значение - NULL в базе и null в JSON.
+type JSON []byte
+
+// MarshalJSON реализует json.Marshaler
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if len(j) == 0 {
+		return []byte("null"), nil
+	}
+	return j, nil
+}
+
+// Scan реализует sql.Scanner
+func (j *JSON) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*j = nil
+	case string:
+		*j = JSON(v)
+	case []byte:
+		*j = append(JSON(nil), v...)
+	default:
+		return fmt.Errorf("cannot scan %T into JSON", src)
+	}
+	return nil
+}
+
+// Value реализует driver.Valuer. Документ передается строкой:
+// []byte драйвер SQLite записал бы как BLOB.
+func (j JSON) Value() (driver.Value, error) {
+	if len(j) == 0 {
+		return nil, nil
+	}
+	return string(j), nil
+}
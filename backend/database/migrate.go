@@ -0,0 +1,314 @@
+package database
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Файлы миграций вшиваются в бинарник, поэтому Docker-образу не нужны исходники.
+// У каждого драйвера свой каталог (migrations/sqlite, migrations/postgres)
+// с одинаковым набором версий: отличается только SQL-диалект.
+// Имя файла: <версия>_<название>.up.sql / <версия>_<название>.down.sql
+//
+//go:embed migrations/*/*.sql
+var migrationFiles embed.FS
+
+// migrationDirs - каталог миграций для каждого драйвера
+var migrationDirs = map[string]string{
+	DriverSQLite:   "migrations/sqlite",
+	DriverPostgres: "migrations/postgres",
+}
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration - одна версия схемы
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // sha256 от up-скрипта
+}
+
+// MigrationStatus - состояние миграции в конкретной базе
+type MigrationStatus struct {
+	Migration
+	Applied         bool
+	AppliedAt       time.Time
+	ChecksumMatches bool
+}
+
+// appliedMigration - строка таблицы schema_migrations
+type appliedMigration struct {
+	Version   int       `db:"version"`
+	Name      string    `db:"name"`
+	Checksum  string    `db:"checksum"`
+	AppliedAt time.Time `db:"applied_at"`
+}
+
+const migrationsTableSchema = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		checksum TEXT NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	);`
+
+// LoadMigrations читает вшитые миграции для драйвера текущего соединения
+// и возвращает их по возрастанию версии. Заодно проверяет, что для других
+// драйверов есть миграции с теми же версиями и названиями.
+func LoadMigrations() ([]Migration, error) {
+	dir, ok := migrationDirs[DB.DriverName()]
+	if !ok {
+		return nil, fmt.Errorf("no migrations for driver %s", DB.DriverName())
+	}
+	migrations, err := loadMigrationsFrom(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, otherDir := range migrationDirs {
+		if otherDir == dir {
+			continue
+		}
+		other, err := loadMigrationsFrom(otherDir)
+		if err != nil {
+			return nil, err
+		}
+		if err := sameVersions(migrations, other); err != nil {
+			return nil, fmt.Errorf("%s and %s are out of sync: %w", dir, otherDir, err)
+		}
+	}
+	return migrations, nil
+}
+
+// sameVersions проверяет, что два набора миграций совпадают по версиям и названиям
+func sameVersions(a, b []Migration) error {
+	if len(a) != len(b) {
+		return fmt.Errorf("%d migrations vs %d", len(a), len(b))
+	}
+	for i := range a {
+		if a[i].Version != b[i].Version || a[i].Name != b[i].Name {
+			return fmt.Errorf("%04d_%s vs %04d_%s", a[i].Version, a[i].Name, b[i].Version, b[i].Name)
+		}
+	}
+	return nil
+}
+
+// loadMigrationsFrom читает миграции из одного каталога
+func loadMigrationsFrom(dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected file in migrations: %s", entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		body, err := fs.ReadFile(migrationFiles, dir+"/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has mismatched names: %s and %s", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(body)
+			sum := sha256.Sum256(body)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// loadAppliedMigrations создает schema_migrations при необходимости и читает примененные версии
+func loadAppliedMigrations() (map[int]appliedMigration, error) {
+	if _, err := DB.Exec(migrationsTableSchema); err != nil {
+		return nil, fmt.Errorf("creating schema_migrations: %w", err)
+	}
+
+	var rows []appliedMigration
+	if err := DB.Select(&rows, "SELECT version, name, checksum, applied_at FROM schema_migrations"); err != nil {
+		return nil, fmt.Errorf("reading schema_migrations: %w", err)
+	}
+
+	applied := make(map[int]appliedMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// MigrationStatuses возвращает состояние всех известных миграций
+func MigrationStatuses() ([]MigrationStatus, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := loadAppliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		status := MigrationStatus{Migration: m}
+		if row, ok := applied[m.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = row.AppliedAt
+			status.ChecksumMatches = row.Checksum == m.Checksum
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// verifyChecksums не дает применять миграции поверх базы,
+// в которой уже примененные скрипты были изменены после выката
+func verifyChecksums(migrations []Migration, applied map[int]appliedMigration) error {
+	known := make(map[int]bool, len(migrations))
+	for _, m := range migrations {
+		known[m.Version] = true
+		if row, ok := applied[m.Version]; ok && row.Checksum != m.Checksum {
+			return fmt.Errorf("checksum mismatch for applied migration %04d_%s: the file was modified after it was applied", m.Version, m.Name)
+		}
+	}
+	for version, row := range applied {
+		if !known[version] {
+			return fmt.Errorf("database has migration %04d_%s which is unknown to this binary", version, row.Name)
+		}
+	}
+	return nil
+}
+
+// MigrateUp применяет все непримененные миграции по порядку.
+// Каждая миграция выполняется в своей транзакции вместе с записью в schema_migrations.
+func MigrateUp() ([]Migration, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := loadAppliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyChecksums(migrations, applied); err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, m := range migrations {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+		if err := applyMigration(m.Up, func(tx *sqlx.Tx) error {
+			_, err := tx.Exec(
+				tx.Rebind("INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)"),
+				m.Version, m.Name, m.Checksum, time.Now().UTC(),
+			)
+			return err
+		}); err != nil {
+			return done, fmt.Errorf("applying migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		log.Printf("Applied migration %04d_%s", m.Version, m.Name)
+		done = append(done, m)
+	}
+	return done, nil
+}
+
+// MigrateDown откатывает steps последних примененных миграций
+func MigrateDown(steps int) ([]Migration, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := loadAppliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyChecksums(migrations, applied); err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		m := migrations[i]
+		if _, ok := applied[m.Version]; !ok {
+			continue
+		}
+		if err := applyMigration(m.Down, func(tx *sqlx.Tx) error {
+			_, err := tx.Exec(tx.Rebind("DELETE FROM schema_migrations WHERE version = ?"), m.Version)
+			return err
+		}); err != nil {
+			return done, fmt.Errorf("reverting migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		log.Printf("Reverted migration %04d_%s", m.Version, m.Name)
+		done = append(done, m)
+	}
+	return done, nil
+}
+
+// applyMigration выполняет скрипт и запись в schema_migrations в одной транзакции
+func applyMigration(script string, record func(tx *sqlx.Tx) error) error {
+	tx, err := DB.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(script); err != nil {
+		return err
+	}
+	if err := record(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// PendingMigrations возвращает число примененных миграций и миграций,
+// известных бинарнику, но еще не примененных к базе
+func PendingMigrations() (applied, pending int, err error) {
+	statuses, err := MigrationStatuses()
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, s := range statuses {
+		if s.Applied {
+			applied++
+		} else {
+			pending++
+		}
+	}
+	return applied, pending, nil
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// postgresDSNEnv - переменная со строкой подключения к пустой базе PostgreSQL.
+// Без нее тесты PostgreSQL пропускаются; тест миграций удаляет в этой базе все таблицы.
+const postgresDSNEnv = "TEST_POSTGRES_DSN"
+
+// useTestDatabase подключает DB к пустой базе драйвера driver на время теста.
+// SQLite создается во временном каталоге; без -tags sqlite_fts5 тест пропускается,
+// потому что миграции поиска требуют FTS5.
+func useTestDatabase(t *testing.T, driver string) {
+	t.Helper()
+
+	var dsn string
+	switch driver {
+	case DriverSQLite:
+		dsn = "file:" + filepath.Join(t.TempDir(), "test.db") + "?_foreign_keys=on"
+	case DriverPostgres:
+		dsn = os.Getenv(postgresDSNEnv)
+		if dsn == "" {
+			t.Skipf("%s is not set", postgresDSNEnv)
+		}
+	}
+
+	conn, err := sqlx.Open(driver, dsn)
+	if err != nil {
+		t.Fatalf("open %s: %v", driver, err)
+	}
+	if driver == DriverSQLite {
+		if _, err := conn.Exec("CREATE VIRTUAL TABLE fts5_probe USING fts5(body); DROP TABLE fts5_probe"); err != nil {
+			conn.Close()
+			t.Skipf("SQLite without FTS5 (run tests with -tags sqlite_fts5): %v", err)
+		}
+	}
+
+	previous := DB
+	DB = conn
+	t.Cleanup(func() {
+		DB = previous
+		conn.Close()
+	})
+}
+
+// tableNames возвращает таблицы текущей базы, кроме служебных таблиц SQLite
+func tableNames(t *testing.T) []string {
+	t.Helper()
+
+	query := "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name"
+	if DB.DriverName() == DriverPostgres {
+		query = "SELECT tablename FROM pg_tables WHERE schemaname = current_schema() ORDER BY tablename"
+	}
+	var names []string
+	if err := DB.Select(&names, query); err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+	return names
+}
+
+func TestMigrationsUpAndDown(t *testing.T) {
+	for _, driver := range []string{DriverSQLite, DriverPostgres} {
+		t.Run(driver, func(t *testing.T) {
+			useTestDatabase(t, driver)
+
+			migrations, err := LoadMigrations()
+			if err != nil {
+				t.Fatalf("LoadMigrations: %v", err)
+			}
+
+			applied, err := MigrateUp()
+			if err != nil {
+				t.Fatalf("MigrateUp: %v", err)
+			}
+			if len(applied) != len(migrations) {
+				t.Fatalf("MigrateUp applied %d migrations, want %d", len(applied), len(migrations))
+			}
+			if again, err := MigrateUp(); err != nil || len(again) != 0 {
+				t.Fatalf("second MigrateUp = %d migrations, %v; want 0, nil", len(again), err)
+			}
+
+			// Откатываем по одной, чтобы каждый down-скрипт выполнился на схеме своей версии
+			for i := len(migrations) - 1; i >= 0; i-- {
+				reverted, err := MigrateDown(1)
+				if err != nil {
+					t.Fatalf("MigrateDown: %v", err)
+				}
+				if len(reverted) != 1 || reverted[0].Version != migrations[i].Version {
+					t.Fatalf("MigrateDown reverted %v, want version %d", reverted, migrations[i].Version)
+				}
+			}
+			if tables := tableNames(t); len(tables) != 1 || tables[0] != "schema_migrations" {
+				t.Fatalf("tables after reverting everything = %v, want only schema_migrations", tables)
+			}
+
+			if _, err := MigrateUp(); err != nil {
+				t.Fatalf("MigrateUp after MigrateDown: %v", err)
+			}
+			appliedCount, pending, err := PendingMigrations()
+			if err != nil {
+				t.Fatalf("PendingMigrations: %v", err)
+			}
+			if appliedCount != len(migrations) || pending != 0 {
+				t.Fatalf("PendingMigrations = %d applied, %d pending; want %d, 0", appliedCount, pending, len(migrations))
+			}
+		})
+	}
+}
+
+func TestMigrationsMatchAcrossDrivers(t *testing.T) {
+	sqlite, err := loadMigrationsFrom(migrationDirs[DriverSQLite])
+	if err != nil {
+		t.Fatalf("load sqlite migrations: %v", err)
+	}
+	postgres, err := loadMigrationsFrom(migrationDirs[DriverPostgres])
+	if err != nil {
+		t.Fatalf("load postgres migrations: %v", err)
+	}
+	if err := sameVersions(sqlite, postgres); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS vacancies;
+DROP TABLE IF EXISTS projects;
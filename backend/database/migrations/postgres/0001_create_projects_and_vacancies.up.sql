@@ -0,0 +1,18 @@
+-- Базовая схема (PostgreSQL). Должна совпадать по смыслу с sqlite/0001.
+CREATE TABLE IF NOT EXISTS projects (
+	id BIGSERIAL PRIMARY KEY,
+	name TEXT NOT NULL,
+	description TEXT,
+	deadline TEXT NOT NULL,
+	experience TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS vacancies (
+	id BIGSERIAL PRIMARY KEY,
+	project_id BIGINT NOT NULL REFERENCES projects(id),
+	name TEXT NOT NULL,
+	description TEXT,
+	field TEXT,
+	country TEXT,
+	experience TEXT
+);
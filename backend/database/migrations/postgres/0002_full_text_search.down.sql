@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_vacancies_search_vector;
+ALTER TABLE vacancies DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_projects_search_vector;
+ALTER TABLE projects DROP COLUMN IF EXISTS search_vector;
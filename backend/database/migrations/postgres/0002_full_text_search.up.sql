@@ -0,0 +1,14 @@
+-- Полнотекстовый поиск (PostgreSQL). Вместо FTS5 - вычисляемые колонки tsvector
+-- с GIN-индексами: название весит больше описания (вес A против B).
+-- Конфигурация 'simple' не зависит от языка, как и токенизатор unicode61 в SQLite.
+ALTER TABLE projects ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+	setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+	setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_projects_search_vector ON projects USING GIN (search_vector);
+
+ALTER TABLE vacancies ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+	setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+	setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_vacancies_search_vector ON vacancies USING GIN (search_vector);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS project_members;
+
+ALTER TABLE projects DROP COLUMN owner_id;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS team_members;
+
+ALTER TABLE vacancies DROP COLUMN closed_at;
+ALTER TABLE vacancies DROP COLUMN openings;
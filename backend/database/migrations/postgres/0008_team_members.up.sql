@@ -0,0 +1,19 @@
+-- Команда проекта и количество мест в вакансиях.
+-- openings - сколько людей еще нужно по вакансии; когда счетчик доходит
+-- до нуля, вакансия закрывается (closed_at).
+ALTER TABLE vacancies ADD COLUMN openings INTEGER NOT NULL DEFAULT 1 CHECK (openings >= 0);
+ALTER TABLE vacancies ADD COLUMN closed_at TIMESTAMPTZ;
+
+-- vacancy_id - вакансия, на которую принят человек; пусто, если его добавили
+-- в команду без вакансии или вакансию потом удалили.
+CREATE TABLE team_members (
+	id BIGSERIAL PRIMARY KEY,
+	project_id BIGINT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+	vacancy_id BIGINT REFERENCES vacancies(id) ON DELETE SET NULL,
+	name TEXT NOT NULL,
+	position TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_team_members_project_id ON team_members(project_id);
+CREATE INDEX idx_team_members_vacancy_id ON team_members(vacancy_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_vacancies_status;
+
+ALTER TABLE vacancies DROP COLUMN status;
@@ -0,0 +1,8 @@
+-- Жизненный цикл вакансии: draft, open, paused, closed, expired.
+-- Вакансии, у которых уже закончились места, переводятся в closed.
+ALTER TABLE vacancies ADD COLUMN status TEXT NOT NULL DEFAULT 'open'
+	CHECK (status IN ('draft', 'open', 'paused', 'closed', 'expired'));
+
+UPDATE vacancies SET status = 'closed' WHERE closed_at IS NOT NULL;
+
+CREATE INDEX idx_vacancies_status ON vacancies(status);
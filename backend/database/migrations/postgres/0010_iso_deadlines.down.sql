@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_projects_deadline;
+
+ALTER TABLE projects ALTER COLUMN deadline TYPE TEXT USING to_char(deadline, 'DD.MM.YYYY');
@@ -0,0 +1,11 @@
+-- Дедлайны проектов становятся датами. Начальные данные хранили их как
+-- DD.MM.YYYY; значения, которые не удается привести к дате, останавливают
+-- миграцию - такие проекты нужно исправить вручную и повторить migrate up.
+ALTER TABLE projects ALTER COLUMN deadline TYPE DATE USING (
+	CASE
+		WHEN deadline ~ '^\d{2}\.\d{2}\.\d{4}$' THEN to_date(deadline, 'DD.MM.YYYY')
+		ELSE deadline::date
+	END
+);
+
+CREATE INDEX idx_projects_deadline ON projects(deadline);
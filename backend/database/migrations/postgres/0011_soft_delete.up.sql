@@ -0,0 +1,8 @@
+-- Мягкое удаление: удаленные проекты и вакансии попадают в корзину и скрываются
+-- из API, но их можно восстановить, пока фоновая задача не удалит их окончательно.
+-- Вакансии, удаленные вместе с проектом, получают ту же отметку deleted_at.
+ALTER TABLE projects ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE vacancies ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX idx_projects_deleted_at ON projects(deleted_at);
+CREATE INDEX idx_vacancies_deleted_at ON vacancies(deleted_at);
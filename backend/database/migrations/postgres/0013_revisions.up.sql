@@ -0,0 +1,17 @@
+-- Ревизии содержимого проектов и вакансий: название, описание, дедлайн и т.д.
+-- Номер ревизии растет отдельно для каждой записи. Ревизия 1 - состояние до первого
+-- изменения, каждая следующая - после очередного изменения. content - поля целиком.
+-- Ревизии удаляются вместе с проектом; ревизии вакансий удаляет очистка корзины.
+CREATE TABLE revisions (
+	id BIGSERIAL PRIMARY KEY,
+	entity_type TEXT NOT NULL CHECK (entity_type IN ('project', 'vacancy')),
+	entity_id BIGINT NOT NULL,
+	project_id BIGINT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+	revision INTEGER NOT NULL,
+	content JSONB NOT NULL,
+	author_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	UNIQUE (entity_type, entity_id, revision)
+);
+
+CREATE INDEX idx_revisions_project_id ON revisions(project_id);
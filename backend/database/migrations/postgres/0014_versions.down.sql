@@ -0,0 +1,2 @@
+ALTER TABLE vacancies DROP COLUMN version;
+ALTER TABLE projects DROP COLUMN version;
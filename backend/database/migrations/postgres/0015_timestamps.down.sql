@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_vacancies_created_at;
+DROP INDEX IF EXISTS idx_projects_created_at;
+ALTER TABLE vacancies DROP COLUMN updated_by;
+ALTER TABLE vacancies DROP COLUMN created_by;
+ALTER TABLE vacancies DROP COLUMN updated_at;
+ALTER TABLE vacancies DROP COLUMN created_at;
+ALTER TABLE projects DROP COLUMN updated_by;
+ALTER TABLE projects DROP COLUMN created_by;
+ALTER TABLE projects DROP COLUMN updated_at;
+ALTER TABLE projects DROP COLUMN created_at;
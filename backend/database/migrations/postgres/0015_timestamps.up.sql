@@ -0,0 +1,41 @@
+-- Когда и кем создан и последний раз изменен проект или вакансия.
+-- updated_at и updated_by меняются вместе с version; updated_by пуст у изменений
+-- без пользователя (начальные данные, фоновые задачи).
+-- Существующие записи заполняются по журналу аудита, а если в нем ничего нет -
+-- временем миграции.
+ALTER TABLE projects ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE projects ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE projects ADD COLUMN created_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE projects ADD COLUMN updated_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE vacancies ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE vacancies ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE vacancies ADD COLUMN created_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE vacancies ADD COLUMN updated_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+UPDATE projects SET
+	created_at = COALESCE((SELECT MIN(e.created_at) FROM audit_events e
+		WHERE e.entity_type = 'project' AND e.entity_id = projects.id), now()),
+	created_by = COALESCE((SELECT e.actor_id FROM audit_events e
+		WHERE e.entity_type = 'project' AND e.entity_id = projects.id AND e.action = 'create'), owner_id);
+UPDATE projects SET
+	updated_at = COALESCE((SELECT MAX(e.created_at) FROM audit_events e
+		WHERE e.entity_type = 'project' AND e.entity_id = projects.id), created_at),
+	updated_by = (SELECT e.actor_id FROM audit_events e
+		WHERE e.entity_type = 'project' AND e.entity_id = projects.id ORDER BY e.id DESC LIMIT 1);
+
+UPDATE vacancies SET
+	created_at = COALESCE((SELECT MIN(e.created_at) FROM audit_events e
+		WHERE e.entity_type = 'vacancy' AND e.entity_id = vacancies.id), now()),
+	created_by = (SELECT e.actor_id FROM audit_events e
+		WHERE e.entity_type = 'vacancy' AND e.entity_id = vacancies.id AND e.action = 'create');
+UPDATE vacancies SET
+	updated_at = COALESCE((SELECT MAX(e.created_at) FROM audit_events e
+		WHERE e.entity_type = 'vacancy' AND e.entity_id = vacancies.id), created_at),
+	updated_by = (SELECT e.actor_id FROM audit_events e
+		WHERE e.entity_type = 'vacancy' AND e.entity_id = vacancies.id ORDER BY e.id DESC LIMIT 1);
+
+ALTER TABLE projects ALTER COLUMN created_at DROP DEFAULT, ALTER COLUMN updated_at DROP DEFAULT;
+ALTER TABLE vacancies ALTER COLUMN created_at DROP DEFAULT, ALTER COLUMN updated_at DROP DEFAULT;
+
+CREATE INDEX idx_projects_created_at ON projects(created_at);
+CREATE INDEX idx_vacancies_created_at ON vacancies(created_at);
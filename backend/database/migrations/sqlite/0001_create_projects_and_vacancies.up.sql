@@ -0,0 +1,20 @@
+-- Базовая схема. IF NOT EXISTS позволяет принять под управление миграций
+-- базы, созданные до появления системы миграций.
+CREATE TABLE IF NOT EXISTS projects (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	description TEXT,
+	deadline TEXT NOT NULL,
+	experience TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS vacancies (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	project_id INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT,
+	field TEXT,
+	country TEXT,
+	experience TEXT,
+	FOREIGN KEY (project_id) REFERENCES projects(id)
+);
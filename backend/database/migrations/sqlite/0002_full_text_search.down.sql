@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS vacancies_fts_update;
+DROP TRIGGER IF EXISTS vacancies_fts_delete;
+DROP TRIGGER IF EXISTS vacancies_fts_insert;
+DROP TABLE IF EXISTS vacancies_fts;
+
+DROP TRIGGER IF EXISTS projects_fts_update;
+DROP TRIGGER IF EXISTS projects_fts_delete;
+DROP TRIGGER IF EXISTS projects_fts_insert;
+DROP TABLE IF EXISTS projects_fts;
@@ -0,0 +1,40 @@
+-- Полнотекстовые индексы FTS5 поверх projects и vacancies.
+-- Индексы хранят только токены (external content), сами данные читаются из основных таблиц.
+-- Триггеры держат индексы в актуальном состоянии при INSERT/UPDATE/DELETE.
+CREATE VIRTUAL TABLE IF NOT EXISTS projects_fts USING fts5(
+	name, description,
+	content='projects', content_rowid='id',
+	tokenize='unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER IF NOT EXISTS projects_fts_insert AFTER INSERT ON projects BEGIN
+	INSERT INTO projects_fts(rowid, name, description) VALUES (new.id, new.name, new.description);
+END;
+CREATE TRIGGER IF NOT EXISTS projects_fts_delete AFTER DELETE ON projects BEGIN
+	INSERT INTO projects_fts(projects_fts, rowid, name, description) VALUES ('delete', old.id, old.name, old.description);
+END;
+CREATE TRIGGER IF NOT EXISTS projects_fts_update AFTER UPDATE ON projects BEGIN
+	INSERT INTO projects_fts(projects_fts, rowid, name, description) VALUES ('delete', old.id, old.name, old.description);
+	INSERT INTO projects_fts(rowid, name, description) VALUES (new.id, new.name, new.description);
+END;
+
+CREATE VIRTUAL TABLE IF NOT EXISTS vacancies_fts USING fts5(
+	name, description,
+	content='vacancies', content_rowid='id',
+	tokenize='unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER IF NOT EXISTS vacancies_fts_insert AFTER INSERT ON vacancies BEGIN
+	INSERT INTO vacancies_fts(rowid, name, description) VALUES (new.id, new.name, new.description);
+END;
+CREATE TRIGGER IF NOT EXISTS vacancies_fts_delete AFTER DELETE ON vacancies BEGIN
+	INSERT INTO vacancies_fts(vacancies_fts, rowid, name, description) VALUES ('delete', old.id, old.name, old.description);
+END;
+CREATE TRIGGER IF NOT EXISTS vacancies_fts_update AFTER UPDATE ON vacancies BEGIN
+	INSERT INTO vacancies_fts(vacancies_fts, rowid, name, description) VALUES ('delete', old.id, old.name, old.description);
+	INSERT INTO vacancies_fts(rowid, name, description) VALUES (new.id, new.name, new.description);
+END;
+
+-- Заполняем индексы данными, которые уже были в базе
+INSERT INTO projects_fts(projects_fts) VALUES ('rebuild');
+INSERT INTO vacancies_fts(vacancies_fts) VALUES ('rebuild');
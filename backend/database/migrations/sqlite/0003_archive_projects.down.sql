@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_vacancies_project_id;
+
+ALTER TABLE projects DROP COLUMN archived_at;
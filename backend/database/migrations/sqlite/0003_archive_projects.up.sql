@@ -0,0 +1,5 @@
+-- Отметка об архивации проекта (политика удаления "archive").
+-- Архивные проекты скрыты из списков, но остаются доступными по ID.
+ALTER TABLE projects ADD COLUMN archived_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_vacancies_project_id ON vacancies(project_id);
@@ -0,0 +1,10 @@
+-- Сессии становятся refresh-токенами с ротацией. При каждом обновлении
+-- старый токен помечается отозванным, новый получает тот же family_id.
+-- Повторное предъявление отозванного токена отзывает все семейство.
+ALTER TABLE sessions ADD COLUMN family_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN revoked_at TIMESTAMP;
+
+-- Уже выданные сессии становятся отдельными семействами
+UPDATE sessions SET family_id = token_hash WHERE family_id = '';
+
+CREATE INDEX idx_sessions_family_id ON sessions(family_id);
@@ -0,0 +1,14 @@
+-- Владелец проекта и участники с ролями.
+-- owner_id - создатель проекта; у проектов, созданных до появления
+-- учетных записей, владельца нет (NULL).
+ALTER TABLE projects ADD COLUMN owner_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE TABLE project_members (
+	project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	role TEXT NOT NULL CHECK (role IN ('owner', 'manager', 'viewer')),
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (project_id, user_id)
+);
+
+CREATE INDEX idx_project_members_user_id ON project_members(user_id);
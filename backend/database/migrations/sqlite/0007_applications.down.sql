@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS application_events;
+DROP TABLE IF EXISTS applications;
@@ -0,0 +1,31 @@
+-- Отклики кандидатов на вакансии и история смены их статусов.
+-- Email хранится в нижнем регистре; один email откликается на вакансию один раз.
+CREATE TABLE applications (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	vacancy_id INTEGER NOT NULL REFERENCES vacancies(id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	email TEXT NOT NULL,
+	phone TEXT NOT NULL DEFAULT '',
+	cover_letter TEXT NOT NULL DEFAULT '',
+	status TEXT NOT NULL DEFAULT 'submitted'
+		CHECK (status IN ('submitted', 'reviewing', 'interview', 'offer', 'hired', 'rejected')),
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	UNIQUE (vacancy_id, email)
+);
+
+CREATE INDEX idx_applications_status ON applications(status);
+
+-- from_status пуст у первой записи - подачи отклика.
+-- changed_by пуст, если статус менял сам кандидат или пользователь удален.
+CREATE TABLE application_events (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+	from_status TEXT,
+	to_status TEXT NOT NULL,
+	note TEXT NOT NULL DEFAULT '',
+	changed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+	created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_application_events_application_id ON application_events(application_id);
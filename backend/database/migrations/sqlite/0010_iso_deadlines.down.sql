@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_projects_deadline;
+DROP TRIGGER IF EXISTS projects_deadline_update;
+DROP TRIGGER IF EXISTS projects_deadline_insert;
+
+UPDATE projects
+SET deadline = substr(deadline, 9, 2) || '.' || substr(deadline, 6, 2) || '.' || substr(deadline, 1, 4);
@@ -0,0 +1,26 @@
+-- Дедлайны проектов хранятся как YYYY-MM-DD, чтобы их можно было сравнивать и
+-- сортировать средствами SQL. Начальные данные хранили их как DD.MM.YYYY.
+UPDATE projects
+SET deadline = substr(deadline, 7, 4) || '-' || substr(deadline, 4, 2) || '-' || substr(deadline, 1, 2)
+WHERE deadline LIKE '__.__.____';
+
+-- Оставшиеся значения, не являющиеся корректной датой, останавливают миграцию:
+-- запись NULL в NOT NULL-столбец откатывает транзакцию. Такие проекты нужно
+-- исправить вручную и повторить migrate up. date() нормализует 31.02 в 03.03,
+-- поэтому сравнение отсекает и несуществующие даты.
+UPDATE projects SET deadline = NULL WHERE date(deadline) IS NOT deadline;
+
+-- Тип столбца в SQLite не меняется, поэтому формат защищаем триггерами
+CREATE TRIGGER projects_deadline_insert BEFORE INSERT ON projects
+WHEN date(NEW.deadline) IS NOT NEW.deadline
+BEGIN
+	SELECT RAISE(ABORT, 'projects.deadline must be a date in YYYY-MM-DD format');
+END;
+
+CREATE TRIGGER projects_deadline_update BEFORE UPDATE OF deadline ON projects
+WHEN date(NEW.deadline) IS NOT NEW.deadline
+BEGIN
+	SELECT RAISE(ABORT, 'projects.deadline must be a date in YYYY-MM-DD format');
+END;
+
+CREATE INDEX idx_projects_deadline ON projects(deadline);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_vacancies_deleted_at;
+DROP INDEX IF EXISTS idx_projects_deleted_at;
+
+-- Без колонок deleted_at содержимое корзины снова стало бы видимым, поэтому удаляем его
+DELETE FROM vacancies WHERE deleted_at IS NOT NULL
+	OR project_id IN (SELECT id FROM projects WHERE deleted_at IS NOT NULL);
+DELETE FROM projects WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE vacancies DROP COLUMN deleted_at;
+ALTER TABLE projects DROP COLUMN deleted_at;
@@ -0,0 +1,22 @@
+-- Журнал аудита изменений проектов и вакансий.
+-- Пишется в той же транзакции, что и само изменение. Внешних ключей на проекты
+-- и вакансии нет: журнал хранится и после окончательного удаления записей.
+-- before_json и after_json - изменившиеся поля до и после (при создании и удалении - запись целиком).
+-- actor_id пуст у изменений без пользователя (начальные данные) или если пользователь удален.
+CREATE TABLE audit_events (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	actor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+	action TEXT NOT NULL CHECK (action IN ('create', 'update', 'delete', 'archive', 'restore')),
+	entity_type TEXT NOT NULL CHECK (entity_type IN ('project', 'vacancy')),
+	entity_id INTEGER NOT NULL,
+	project_id INTEGER NOT NULL,
+	before_json TEXT,
+	after_json TEXT,
+	request_id TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_audit_events_entity ON audit_events(entity_type, entity_id);
+CREATE INDEX idx_audit_events_project_id ON audit_events(project_id);
+CREATE INDEX idx_audit_events_actor_id ON audit_events(actor_id);
+CREATE INDEX idx_audit_events_created_at ON audit_events(created_at);
@@ -0,0 +1,4 @@
+-- Версии проектов и вакансий для оптимистичных блокировок: каждое изменение записи
+-- увеличивает version на 1. Версия отдается в заголовке ETag и проверяется по If-Match.
+ALTER TABLE projects ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE vacancies ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -2,24 +2,369 @@
 
 package database
 
+import "time"
+
 // Vacancy структура для соответствия таблице vacancies
 // Используем nullable типы или указатели для полей, которые могут быть NULL в БД,
 // или оставляем как есть, если они всегда NOT NULL (кроме description)
 type Vacancy struct {
-	ID          uint   `db:"id" json:"id"`                     // Для sqlx используем db тег, для JSON - json
+	ID          uint   `db:"id" json:"id"`                 // Для sqlx используем db тег, для JSON - json
 	ProjectID   uint   `db:"project_id" json:"project_id"` // Имя поля совпадает с колонкой
-	Name        string `db:"name" json:"name"`
-	Description string `db:"description" json:"description"` // Оставляем string, sqlx справится с NULL -> ""
-	Field       string `db:"field" json:"field"`
-	Country     string `db:"country" json:"country"`
-	Experience  string `db:"experience" json:"experience"`
+	Name        string `db:"name" json:"name" binding:"required,notblank,max=200"`
+	Description string `db:"description" json:"description" binding:"max=5000"` // Оставляем string, sqlx справится с NULL -> ""
+	Field       string `db:"field" json:"field" binding:"omitempty,oneof=Design Development Marketing" enums:"Design,Development,Marketing"`
+	Country     string `db:"country" json:"country" binding:"omitempty,iso3166_1_alpha2" example:"DE"`       // ISO 3166-1 alpha-2
+	Experience  string `db:"experience" json:"experience" binding:"omitempty,experience" example:"3+ years"` // см. handlers.experiencePattern
+	// Openings - сколько людей еще нужно; уменьшается, когда человека принимают в команду по вакансии
+	Openings int `db:"openings" json:"openings" binding:"gte=0" example:"2"`
+	// Status - этап жизненного цикла; отклики принимаются только в статусе open
+	Status VacancyStatus `db:"status" json:"status" enums:"draft,open,paused,closed,expired" example:"open"`
+	// ClosedAt заполняется, когда вакансия закрывается: вручную или когда Openings доходит до нуля
+	ClosedAt *time.Time `db:"closed_at" json:"closed_at,omitempty"`
+	// DeletedAt заполняется, когда вакансия (или ее проект) удалена в корзину
+	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+	// Version увеличивается при каждом изменении; отдается в ETag и проверяется по If-Match
+	Version int `db:"version" json:"version" readonly:"true" example:"3"`
+	Timestamps
+}
+
+// Timestamps - когда и кем запись создана и последний раз изменена.
+// Заполняются хранилищем; UpdatedAt и UpdatedBy меняются вместе с Version.
+type Timestamps struct {
+	CreatedAt time.Time `db:"created_at" json:"created_at" readonly:"true"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at" readonly:"true"`
+	// CreatedBy и UpdatedBy пусты у изменений без пользователя (начальные данные, фоновые задачи)
+	CreatedBy *uint `db:"created_by" json:"created_by,omitempty" readonly:"true"`
+	UpdatedBy *uint `db:"updated_by" json:"updated_by,omitempty" readonly:"true"`
+}
+
+// VacancyStatus - этап жизненного цикла вакансии
+type VacancyStatus string
+
+const (
+	VacancyDraft   VacancyStatus = "draft"   // черновик, еще не опубликована
+	VacancyOpen    VacancyStatus = "open"    // принимает отклики
+	VacancyPaused  VacancyStatus = "paused"  // временно не принимает отклики
+	VacancyClosed  VacancyStatus = "closed"  // набор завершен
+	VacancyExpired VacancyStatus = "expired" // дедлайн проекта прошел
+)
+
+// VacancyStatuses - все статусы вакансий
+var VacancyStatuses = []VacancyStatus{VacancyDraft, VacancyOpen, VacancyPaused, VacancyClosed, VacancyExpired}
+
+// vacancyTransitions - куда вакансию можно перевести вручную из каждого статуса.
+// В expired вакансию переводит только фоновая задача, когда проходит дедлайн проекта.
+var vacancyTransitions = map[VacancyStatus][]VacancyStatus{
+	VacancyDraft:   {VacancyOpen, VacancyClosed},
+	VacancyOpen:    {VacancyPaused, VacancyClosed},
+	VacancyPaused:  {VacancyOpen, VacancyClosed},
+	VacancyClosed:  {VacancyOpen},
+	VacancyExpired: {VacancyOpen, VacancyClosed},
+}
+
+// NextStatuses возвращает статусы, в которые вакансию можно перевести из s
+func (s VacancyStatus) NextStatuses() []VacancyStatus {
+	return append([]VacancyStatus{}, vacancyTransitions[s]...)
+}
+
+// CanBecome сообщает, разрешен ли ручной переход из s в next
+func (s VacancyStatus) CanBecome(next VacancyStatus) bool {
+	for _, allowed := range vacancyTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// VacancyFields - допустимые значения поля field у вакансии
+var VacancyFields = []string{"Design", "Development", "Marketing"}
+
+// VacancyWithProject - вакансия вместе с основными данными её проекта.
+// Используется в поиске по всем вакансиям, чтобы фронтенду не нужно было
+// отдельно запрашивать каждый проект.
+type VacancyWithProject struct {
+	Vacancy
+	ProjectName     string `db:"project_name" json:"project_name"`
+	ProjectDeadline Date   `db:"project_deadline" json:"project_deadline" swaggertype:"string" format:"date" example:"2026-12-31"`
+}
+
+// SearchResult - одна запись результата полнотекстового поиска.
+// Type показывает, что найдено: "project" или "vacancy".
+// Name и Snippet содержат найденные слова, обернутые в <mark>...</mark>.
+type SearchResult struct {
+	Type      string  `db:"type" json:"type" enums:"project,vacancy"`
+	ID        uint    `db:"id" json:"id"`
+	ProjectID uint    `db:"project_id" json:"project_id"`
+	Name      string  `db:"name" json:"name"`
+	Snippet   string  `db:"snippet" json:"snippet"`
+	Rank      float64 `db:"rank" json:"rank"` // bm25: чем меньше, тем релевантнее
+}
+
+// TrashItem - удаленный проект или вакансия в корзине.
+// Вакансии, удаленные вместе с проектом, отдельно не показываются: они восстанавливаются вместе с ним.
+type TrashItem struct {
+	Type      string    `db:"type" json:"type" enums:"project,vacancy"`
+	ID        uint      `db:"id" json:"id"`
+	ProjectID uint      `db:"project_id" json:"project_id"`
+	Name      string    `db:"name" json:"name"`
+	DeletedAt time.Time `db:"deleted_at" json:"deleted_at"`
+	// PurgeAt - когда запись будет удалена окончательно; пусто, если корзина не очищается
+	PurgeAt *time.Time `db:"-" json:"purge_at,omitempty"`
 }
 
 // Можешь также определить здесь структуру Project, если она нужна в обработчиках
 type Project struct {
-    ID          uint   `db:"id" json:"id"`
-    Name        string `db:"name" json:"name"`
-    Description string `db:"description" json:"description"`
-    Deadline    string `db:"deadline" json:"deadline"`
-    Experience  string `db:"experience" json:"experience"`
-}
\ No newline at end of file
+	ID          uint   `db:"id" json:"id"`
+	Name        string `db:"name" json:"name" binding:"required,notblank,max=200"`
+	Description string `db:"description" json:"description" binding:"max=5000"`
+	// Deadline принимается как YYYY-MM-DD или DD.MM.YYYY, отдается как YYYY-MM-DD
+	Deadline   Date   `db:"deadline" json:"deadline" binding:"required" swaggertype:"string" format:"date" example:"2026-12-31"`
+	Experience string `db:"experience" json:"experience" binding:"required,experience" example:"3+ years"`
+	// Заполняется, когда проект архивирован вместо удаления (политика удаления "archive")
+	ArchivedAt *time.Time `db:"archived_at" json:"archived_at,omitempty"`
+	// OwnerID - создатель проекта; пусто у проектов, созданных до появления учетных записей
+	OwnerID *uint `db:"owner_id" json:"owner_id,omitempty"`
+	// DeletedAt заполняется, когда проект удален в корзину
+	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+	// Version увеличивается при каждом изменении; отдается в ETag и проверяется по If-Match
+	Version int `db:"version" json:"version" readonly:"true" example:"3"`
+	Timestamps
+}
+
+// ProjectRole - роль участника проекта
+type ProjectRole string
+
+const (
+	// RoleOwner может все, включая удаление проекта и управление участниками
+	RoleOwner ProjectRole = "owner"
+	// RoleManager может изменять проект и его вакансии
+	RoleManager ProjectRole = "manager"
+	// RoleViewer - участник без права изменений
+	RoleViewer ProjectRole = "viewer"
+)
+
+// ProjectRoles - допустимые роли участников
+var ProjectRoles = []ProjectRole{RoleOwner, RoleManager, RoleViewer}
+
+// ProjectMember - участник проекта вместе с основными данными пользователя
+type ProjectMember struct {
+	ProjectID uint        `db:"project_id" json:"project_id"`
+	UserID    uint        `db:"user_id" json:"user_id"`
+	Role      ProjectRole `db:"role" json:"role" enums:"owner,manager,viewer"`
+	Email     string      `db:"email" json:"email"`
+	Name      string      `db:"name" json:"name"`
+	CreatedAt time.Time   `db:"created_at" json:"created_at"`
+}
+
+// User - учетная запись. Хеш пароля никогда не отдается в JSON.
+type User struct {
+	ID           uint      `db:"id" json:"id"`
+	Email        string    `db:"email" json:"email"`
+	Name         string    `db:"name" json:"name"`
+	PasswordHash string    `db:"password_hash" json:"-"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+}
+
+// Session - выданный пользователю refresh-токен. Сам токен не хранится, только его sha256.
+// Все токены, полученные ротацией из одного входа, имеют общий FamilyID.
+type Session struct {
+	ID        uint       `db:"id"`
+	UserID    uint       `db:"user_id"`
+	FamilyID  string     `db:"family_id"`
+	TokenHash string     `db:"token_hash"`
+	CreatedAt time.Time  `db:"created_at"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
+// ApplicationStatus - этап рассмотрения отклика на вакансию
+type ApplicationStatus string
+
+const (
+	ApplicationSubmitted ApplicationStatus = "submitted" // кандидат откликнулся
+	ApplicationReviewing ApplicationStatus = "reviewing" // отклик рассматривается
+	ApplicationInterview ApplicationStatus = "interview" // назначено собеседование
+	ApplicationOffer     ApplicationStatus = "offer"     // кандидату сделано предложение
+	ApplicationHired     ApplicationStatus = "hired"     // кандидат принят
+	ApplicationRejected  ApplicationStatus = "rejected"  // отказ
+)
+
+// ApplicationStatuses - все статусы откликов в порядке прохождения
+var ApplicationStatuses = []ApplicationStatus{
+	ApplicationSubmitted, ApplicationReviewing, ApplicationInterview,
+	ApplicationOffer, ApplicationHired, ApplicationRejected,
+}
+
+// applicationTransitions - куда можно перевести отклик из каждого статуса.
+// Отклик движется только вперед; отказать можно на любом этапе до найма.
+// hired и rejected - конечные статусы.
+var applicationTransitions = map[ApplicationStatus][]ApplicationStatus{
+	ApplicationSubmitted: {ApplicationReviewing, ApplicationRejected},
+	ApplicationReviewing: {ApplicationInterview, ApplicationRejected},
+	ApplicationInterview: {ApplicationOffer, ApplicationRejected},
+	ApplicationOffer:     {ApplicationHired, ApplicationRejected},
+}
+
+// NextStatuses возвращает статусы, в которые можно перевести отклик из s
+func (s ApplicationStatus) NextStatuses() []ApplicationStatus {
+	return append([]ApplicationStatus{}, applicationTransitions[s]...)
+}
+
+// CanBecome сообщает, разрешен ли переход из s в next
+func (s ApplicationStatus) CanBecome(next ApplicationStatus) bool {
+	for _, allowed := range applicationTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Application - отклик кандидата на вакансию.
+// ProjectID и VacancyName подставляются из вакансии при чтении.
+type Application struct {
+	ID          uint              `db:"id" json:"id"`
+	VacancyID   uint              `db:"vacancy_id" json:"vacancy_id"`
+	ProjectID   uint              `db:"project_id" json:"project_id"`
+	VacancyName string            `db:"vacancy_name" json:"vacancy_name"`
+	Name        string            `db:"name" json:"name"`
+	Email       string            `db:"email" json:"email"`
+	Phone       string            `db:"phone" json:"phone"`
+	CoverLetter string            `db:"cover_letter" json:"cover_letter"`
+	Status      ApplicationStatus `db:"status" json:"status" enums:"submitted,reviewing,interview,offer,hired,rejected"`
+	CreatedAt   time.Time         `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time         `db:"updated_at" json:"updated_at"`
+}
+
+// ApplicationEvent - запись истории статусов отклика.
+// У первой записи (подачи отклика) FromStatus пуст.
+type ApplicationEvent struct {
+	ID            uint              `db:"id" json:"id"`
+	ApplicationID uint              `db:"application_id" json:"application_id"`
+	FromStatus    ApplicationStatus `db:"from_status" json:"from_status,omitempty"`
+	ToStatus      ApplicationStatus `db:"to_status" json:"to_status"`
+	Note          string            `db:"note" json:"note,omitempty"`
+	ChangedBy     *uint             `db:"changed_by" json:"changed_by,omitempty"`
+	CreatedAt     time.Time         `db:"created_at" json:"created_at"`
+}
+
+// TeamMember - человек в команде проекта.
+// VacancyID - вакансия, на которую он принят; пусто, если его добавили без вакансии.
+type TeamMember struct {
+	ID        uint      `db:"id" json:"id"`
+	ProjectID uint      `db:"project_id" json:"project_id"`
+	VacancyID *uint     `db:"vacancy_id" json:"vacancy_id,omitempty"`
+	Name      string    `db:"name" json:"name"`
+	Position  string    `db:"position" json:"position"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// ProjectStaffing - укомплектованность проекта
+type ProjectStaffing struct {
+	Headcount     int `db:"headcount" json:"headcount" example:"3"`           // Людей в команде
+	OpenPositions int `db:"open_positions" json:"open_positions" example:"2"` // Свободных мест в открытых вакансиях проекта
+}
+
+// AuditAction - вид изменения в журнале аудита
+type AuditAction string
+
+const (
+	AuditCreate  AuditAction = "create"
+	AuditUpdate  AuditAction = "update"
+	AuditDelete  AuditAction = "delete"  // удаление в корзину
+	AuditArchive AuditAction = "archive" // архивация вместо удаления (политика "archive")
+	AuditRestore AuditAction = "restore" // восстановление из корзины
+)
+
+// AuditActions - все виды изменений
+var AuditActions = []AuditAction{AuditCreate, AuditUpdate, AuditDelete, AuditArchive, AuditRestore}
+
+// AuditEvent - запись журнала аудита об изменении проекта или вакансии.
+// Before и After содержат только изменившиеся поля; при создании Before пуст,
+// при удалении After пуст, а другая сторона содержит запись целиком.
+type AuditEvent struct {
+	ID uint `db:"id" json:"id"`
+	// ActorID - кто внес изменение; пусто у изменений без пользователя (например, начальных данных)
+	ActorID    *uint       `db:"actor_id" json:"actor_id"`
+	ActorName  string      `db:"actor_name" json:"actor_name,omitempty"`
+	Action     AuditAction `db:"action" json:"action" enums:"create,update,delete,archive,restore"`
+	EntityType string      `db:"entity_type" json:"entity_type" enums:"project,vacancy"`
+	EntityID   uint        `db:"entity_id" json:"entity_id"`
+	// ProjectID - проект записи (для проекта совпадает с EntityID)
+	ProjectID uint      `db:"project_id" json:"project_id"`
+	Before    JSON      `db:"before_json" json:"before" swaggertype:"object"`
+	After     JSON      `db:"after_json" json:"after" swaggertype:"object"`
+	RequestID string    `db:"request_id" json:"request_id,omitempty"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// ProjectContent - содержимое проекта, версии которого хранятся в ревизиях
+type ProjectContent struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Deadline    Date   `json:"deadline" swaggertype:"string" format:"date"`
+	Experience  string `json:"experience"`
+}
+
+// Content возвращает текущее содержимое проекта
+func (p Project) Content() ProjectContent {
+	return ProjectContent{Name: p.Name, Description: p.Description, Deadline: p.Deadline, Experience: p.Experience}
+}
+
+// SetContent заменяет содержимое проекта; остальные поля не меняются
+func (p *Project) SetContent(content ProjectContent) {
+	p.Name = content.Name
+	p.Description = content.Description
+	p.Deadline = content.Deadline
+	p.Experience = content.Experience
+}
+
+// VacancyContent - содержимое вакансии, версии которого хранятся в ревизиях.
+// Число мест и статус сюда не входят: это состояние набора, а не текст вакансии.
+type VacancyContent struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Field       string `json:"field"`
+	Country     string `json:"country"`
+	Experience  string `json:"experience"`
+}
+
+// Content возвращает текущее содержимое вакансии
+func (v Vacancy) Content() VacancyContent {
+	return VacancyContent{Name: v.Name, Description: v.Description, Field: v.Field, Country: v.Country, Experience: v.Experience}
+}
+
+// SetContent заменяет содержимое вакансии; остальные поля не меняются
+func (v *Vacancy) SetContent(content VacancyContent) {
+	v.Name = content.Name
+	v.Description = content.Description
+	v.Field = content.Field
+	v.Country = content.Country
+	v.Experience = content.Experience
+}
+
+// Revision - сохраненная версия содержимого проекта или вакансии.
+// Ревизия 1 - состояние до первого изменения, каждая следующая - после очередного изменения.
+type Revision struct {
+	ID         uint   `db:"id" json:"-"`
+	EntityType string `db:"entity_type" json:"entity_type" enums:"project,vacancy"`
+	EntityID   uint   `db:"entity_id" json:"entity_id"`
+	ProjectID  uint   `db:"project_id" json:"project_id"`
+	Revision   int    `db:"revision" json:"revision" example:"3"`
+	// Content - содержимое целиком (ProjectContent или VacancyContent)
+	Content JSON `db:"content" json:"content" swaggertype:"object"`
+	// Changes - чем ревизия отличается от предыдущей; у первой ревизии пусто
+	Changes []FieldChange `db:"-" json:"changes"`
+	// AuthorID - кто внес изменение; пусто у первой ревизии и у изменений без пользователя
+	AuthorID   *uint     `db:"author_id" json:"author_id"`
+	AuthorName string    `db:"author_name" json:"author_name,omitempty"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+}
+
+// FieldChange - изменение одного поля между двумя ревизиями
+type FieldChange struct {
+	Field string `json:"field" example:"deadline"`
+	From  any    `json:"from" swaggertype:"string" example:"2026-11-30"`
+	To    any    `json:"to" swaggertype:"string" example:"2026-12-31"`
+}
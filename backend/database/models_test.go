@@ -0,0 +1,56 @@
+package database
+
+import "testing"
+
+func TestVacancyStatusCanBecome(t *testing.T) {
+	tests := []struct {
+		from, to VacancyStatus
+		want     bool
+	}{
+		{VacancyDraft, VacancyOpen, true},
+		{VacancyDraft, VacancyClosed, true},
+		{VacancyDraft, VacancyPaused, false},
+		{VacancyOpen, VacancyPaused, true},
+		{VacancyOpen, VacancyClosed, true},
+		{VacancyOpen, VacancyDraft, false},
+		{VacancyOpen, VacancyOpen, false},
+		{VacancyPaused, VacancyOpen, true},
+		{VacancyPaused, VacancyClosed, true},
+		{VacancyClosed, VacancyOpen, true},
+		{VacancyClosed, VacancyPaused, false},
+		{VacancyExpired, VacancyOpen, true},
+		{VacancyExpired, VacancyClosed, true},
+		// В expired переводит только фоновая задача
+		{VacancyOpen, VacancyExpired, false},
+		{VacancyPaused, VacancyExpired, false},
+	}
+	for _, tt := range tests {
+		if got := tt.from.CanBecome(tt.to); got != tt.want {
+			t.Errorf("%s.CanBecome(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestApplicationStatusCanBecome(t *testing.T) {
+	tests := []struct {
+		from, to ApplicationStatus
+		want     bool
+	}{
+		{ApplicationSubmitted, ApplicationReviewing, true},
+		{ApplicationSubmitted, ApplicationRejected, true},
+		{ApplicationSubmitted, ApplicationInterview, false},
+		{ApplicationReviewing, ApplicationInterview, true},
+		{ApplicationReviewing, ApplicationSubmitted, false},
+		{ApplicationInterview, ApplicationOffer, true},
+		{ApplicationOffer, ApplicationHired, true},
+		{ApplicationOffer, ApplicationRejected, true},
+		// hired и rejected - конечные статусы
+		{ApplicationHired, ApplicationRejected, false},
+		{ApplicationRejected, ApplicationReviewing, false},
+	}
+	for _, tt := range tests {
+		if got := tt.from.CanBecome(tt.to); got != tt.want {
+			t.Errorf("%s.CanBecome(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package database
+
+// FindOrphanedVacancies возвращает вакансии, ссылающиеся на несуществующие проекты.
+// Такие строки могли появиться, пока внешние ключи SQLite не были включены.
+func FindOrphanedVacancies() ([]Vacancy, error) {
+	orphans := []Vacancy{}
+	query := `
+		SELECT id, project_id, name,
+			COALESCE(description, '') AS description, COALESCE(field, '') AS field,
+			COALESCE(country, '') AS country, COALESCE(experience, '') AS experience
+		FROM vacancies
+		WHERE project_id NOT IN (SELECT id FROM projects)
+		ORDER BY id`
+	err := DB.Select(&orphans, query)
+	return orphans, err
+}
+
+// DeleteOrphanedVacancies удаляет вакансии, ссылающиеся на несуществующие проекты
+func DeleteOrphanedVacancies() (int64, error) {
+	result, err := DB.Exec("DELETE FROM vacancies WHERE project_id NOT IN (SELECT id FROM projects)")
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
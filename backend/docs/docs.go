@@ -15,33 +15,78 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/projects": {
+        "/applications/{id}": {
             "get": {
-                "description": "Retrieve all projects from the database",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Application with its status history and the statuses it can move to next. Requires the owner or manager role in the vacancy's project.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Projects"
+                    "applications"
+                ],
+                "summary": "Get an application",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all projects",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Application with history",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Project"
-                            }
+                            "$ref": "#/definitions/handlers.ApplicationDetails"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid application ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the vacancy's project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Application not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new project by providing the project details",
+            }
+        },
+        "/applications/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Applications move forward only: submitted → reviewing → interview → offer → hired, and can be rejected at any step before hiring. hired and rejected are final. Every change is recorded in the history with its author and an optional note. Requires the owner or manager role in the vacancy's project.",
                 "consumes": [
                     "application/json"
                 ],
@@ -49,33 +94,87 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Projects"
+                    "applications"
                 ],
-                "summary": "Create a new project",
+                "summary": "Move an application to another status",
                 "parameters": [
                     {
-                        "description": "Project data",
-                        "name": "project",
+                        "type": "integer",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status and an optional note",
+                        "name": "status",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Project"
+                            "$ref": "#/definitions/handlers.ChangeStatusRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "Status changed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ApplicationDetails"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid application ID format or malformed JSON",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the vacancy's project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Application not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "The application cannot move to this status from its current one",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.InvalidTransitionResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Missing or unknown status",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/models.Project"
+                            "$ref": "#/definitions/apierror.Problem"
                         }
                     }
                 }
             }
         },
-        "/projects/{id}": {
+        "/audit": {
             "get": {
-                "description": "Retrieve a project from the database by its ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a page of the audit log, newest first. Every change of a project or vacancy is recorded with its author, request ID and the changed fields before and after. Only events of projects where the user is an owner or manager are listed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -83,36 +182,109 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Projects"
+                    "Audit"
                 ],
-                "summary": "Get a project by ID",
+                "summary": "List audit events",
                 "parameters": [
+                    {
+                        "enum": [
+                            "project",
+                            "vacancy"
+                        ],
+                        "type": "string",
+                        "description": "Restrict results to one entity type",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only events of this project or vacancy (use with entity_type)",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only events of this project and its vacancies",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only changes made by this user",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete",
+                            "archive",
+                            "restore"
+                        ],
+                        "type": "string",
+                        "description": "Only this kind of change",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or after this time (RFC 3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events before this time (RFC 3339)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Page of audit events",
                         "schema": {
-                            "$ref": "#/definitions/models.Project"
+                            "$ref": "#/definitions/handlers.AuditListResponse"
                         }
                     },
-                    "404": {
-                        "description": "Project not found",
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Problem"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Edit a project by ID",
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Exchange email and password for an access token and a refresh token",
                 "consumes": [
                     "application/json"
                 ],
@@ -120,45 +292,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Projects"
+                    "auth"
                 ],
-                "summary": "Edit an existing project",
+                "summary": "Log in",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated project data",
-                        "name": "project",
+                        "description": "Email and password",
+                        "name": "credentials",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Project"
+                            "$ref": "#/definitions/handlers.LoginRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Tokens issued",
                         "schema": {
-                            "$ref": "#/definitions/models.Project"
+                            "$ref": "#/definitions/handlers.AuthResponse"
                         }
                     },
-                    "404": {
-                        "description": "Project not found",
+                    "400": {
+                        "description": "Malformed JSON",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid email or password",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Missing email or password",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a project by ID",
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Revoke the refresh token and every token rotated from the same login. Access tokens already issued stay valid until they expire.",
                 "consumes": [
                     "application/json"
                 ],
@@ -166,79 +350,91 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Projects"
+                    "auth"
                 ],
-                "summary": "Delete an existing project",
+                "summary": "Log out",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Refresh token",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RefreshRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "204": {
-                        "description": "No Content",
+                        "description": "Tokens revoked"
+                    },
+                    "400": {
+                        "description": "Malformed JSON",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Project not found",
+                    "401": {
+                        "description": "Unknown refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Missing refresh token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
                         }
                     }
                 }
             }
         },
-        "/projects/{id}/vacancies": {
+        "/auth/me": {
             "get": {
-                "description": "Retrieve all vacancies for a given project by project ID",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Return the user that owns the access token",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Vacancies"
-                ],
-                "summary": "Get all vacancies for a project",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Current user",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Authenticated user",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Vacancy"
-                            }
+                            "$ref": "#/definitions/database.User"
                         }
                     },
-                    "404": {
-                        "description": "Project not found",
+                    "401": {
+                        "description": "Missing, invalid or expired token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Problem"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/refresh": {
             "post": {
-                "description": "Create a new vacancy by providing the vacancy details and the project ID",
+                "description": "Exchange a refresh token for a new access token and a new refresh token. The old refresh token stops working (rotation); presenting it again revokes every token issued from the same login.",
                 "consumes": [
                     "application/json"
                 ],
@@ -246,47 +442,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Vacancies"
+                    "auth"
                 ],
-                "summary": "Create a new vacancy for a project",
+                "summary": "Refresh tokens",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Vacancy data",
-                        "name": "vacancy",
+                        "description": "Refresh token",
+                        "name": "token",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Vacancy"
+                            "$ref": "#/definitions/handlers.RefreshRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "New tokens issued",
                         "schema": {
-                            "$ref": "#/definitions/models.Vacancy"
+                            "$ref": "#/definitions/handlers.AuthResponse"
                         }
                     },
-                    "404": {
-                        "description": "Project not found",
+                    "400": {
+                        "description": "Malformed JSON",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unknown, expired, revoked or reused refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Missing refresh token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
                         }
                     }
                 }
             }
         },
-        "/vacancies/{id}": {
-            "put": {
-                "description": "Edit a vacancy by ID",
+        "/auth/register": {
+            "post": {
+                "description": "Create an account and log in right away. The email is case-insensitive; the password must be 8-72 bytes long.",
                 "consumes": [
                     "application/json"
                 ],
@@ -294,130 +500,4477 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Vacancies"
+                    "auth"
                 ],
-                "summary": "Edit an existing vacancy",
+                "summary": "Register a new user",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Vacancy ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated vacancy data",
-                        "name": "vacancy",
+                        "description": "Account data",
+                        "name": "user",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Vacancy"
+                            "$ref": "#/definitions/handlers.RegisterRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Account created, tokens issued",
                         "schema": {
-                            "$ref": "#/definitions/models.Vacancy"
+                            "$ref": "#/definitions/handlers.AuthResponse"
                         }
                     },
-                    "404": {
-                        "description": "Vacancy not found",
+                    "400": {
+                        "description": "Malformed JSON",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Email is already registered",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid email, name or password",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a vacancy by its ID",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Returns 200 as long as the process is running and serving HTTP. Does not touch the database.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Vacancies"
-                ],
-                "summary": "Delete a vacancy by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Vacancy ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "health"
                 ],
+                "summary": "Liveness probe",
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "Process is alive",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.LivenessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects": {
+            "get": {
+                "description": "Retrieve a page of projects with optional sorting and filtering. Use sort=created_at\u0026order=desc for the newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Get projects",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "name",
+                            "deadline",
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by exact experience value, e.g. 3+ years",
+                        "name": "experience",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only projects with deadline on or after this date (YYYY-MM-DD or DD.MM.YYYY)",
+                        "name": "deadline_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only projects with deadline on or before this date (YYYY-MM-DD or DD.MM.YYYY)",
+                        "name": "deadline_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Include archived projects",
+                        "name": "include_archived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of projects",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProjectListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new project by providing the project details. The authenticated user becomes its owner. The deadline is accepted as YYYY-MM-DD or DD.MM.YYYY, is returned as YYYY-MM-DD and must not be in the past.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Create a new project",
+                "parameters": [
+                    {
+                        "description": "Project data (ID can be omitted or 0)",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/database.Project"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Project created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/database.Project"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Project version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed JSON",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid project fields, including a malformed or past deadline",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}": {
+            "get": {
+                "description": "Retrieve a project by its ID, with its team headcount and the number of open positions across its vacancies. The ETag header holds the project version; send it in If-None-Match to get 304 while the project is unchanged. Headcount and open positions are not part of the version.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Get a project by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved project",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProjectDetails"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Project version, e.g. \\\"3\\"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Project has not changed since the given ETag"
+                    },
+                    "400": {
+                        "description": "Invalid project ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
                         }
                     },
                     "404": {
-                        "description": "Vacancy not found",
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Edit a project by ID. Requires the owner or manager role in the project. The deadline is accepted as YYYY-MM-DD or DD.MM.YYYY; a past deadline is allowed here so that overdue projects stay editable.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Edit an existing project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project being edited; required if the server enforces it",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Updated project data (ID and version in body are ignored)",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/database.Project"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Project updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/database.Project"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New project version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID format, malformed JSON or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Project changed since the ETag in If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid values in the fields the request changes",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is required but missing",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a project by ID. What happens to its vacancies depends on the server's delete policy: \"cascade\" moves them to the trash together with the project, \"restrict\" refuses with 409 while the project has vacancies, \"archive\" keeps the data and marks the project as archived. Deleted projects can be restored with POST /projects/{id}/restore until the trash is purged. Only project owners can delete it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Delete an existing project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project being deleted; required if the server enforces it",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Project deleted (or archived) successfully"
+                    },
+                    "400": {
+                        "description": "Invalid project ID format or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner of the project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Project still has vacancies (restrict policy)",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeleteProjectConflict"
+                        }
+                    },
+                    "412": {
+                        "description": "Project changed since the ETag in If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is required but missing",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update only the fields present in the body, following JSON Merge Patch (RFC 7396): omitted fields keep their values and null clears a field. The patched project must pass the same validation as in PUT. Requires the owner or manager role in the project.",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Partially update a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project being edited; required if the server enforces it",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/database.Project"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Project updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/database.Project"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New project version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID format, malformed patch or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Project changed since the ETag in If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Body is neither application/json nor application/merge-patch+json",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid values in the fields the patch changes",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is required but missing",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/applications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Applications for all vacancies of a project, newest first. Requires the owner or manager role in the project.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "applications"
+                ],
+                "summary": "List applications for a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "submitted",
+                            "reviewing",
+                            "interview",
+                            "offer",
+                            "hired",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of applications",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ApplicationListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID format or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List members of a project with their roles. Available to any member of the project.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "List project members",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Project members, owners first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/database.ProjectMember"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a member of the project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/members/{user_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Give a user the owner, manager or viewer role in the project. Only project owners can manage members. The last owner cannot be demoted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "Add a member or change their role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated list of project members",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/database.ProjectMember"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format or malformed JSON",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner of the project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Project or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Cannot demote the last owner",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Missing or unknown role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Take away the user's role in the project. Only project owners can manage members. The last owner cannot be removed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "Remove a project member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Member removed"
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner of the project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Project or member not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Cannot remove the last owner",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bring a project back from the trash together with the vacancies that were deleted with it. Requires the owner role in the project.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore a deleted project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored project",
+                        "schema": {
+                            "$ref": "#/definitions/database.Project"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner of the project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found or not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a page of saved versions of the project's name, description, deadline and experience, newest first. Revision 1 is the content before the first edit; every edit adds the next one. changes lists the fields that differ from the previous revision. Requires the owner or manager role in the project.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "List project revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of revisions",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RevisionListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/revisions/{rev}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bring the project's name, description, deadline and experience back to the given revision. The restore is saved as a new revision; nothing in the history is removed. Requires the owner or manager role in the project.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Restore a project revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "rev",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project being changed",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Project with the restored content",
+                        "schema": {
+                            "$ref": "#/definitions/database.Project"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New project version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID, revision number or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Project or revision not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Project changed since the ETag in If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/team": {
+            "get": {
+                "description": "People hired onto the project, in the order they joined, with the headcount and the number of open positions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Get the project team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Project team",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record that someone has been hired onto the project. With vacancy_id the person fills one opening of that vacancy; the vacancy closes when no openings are left. Requires the owner or manager role in the project.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Add a person to the project team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Team member",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AddTeamMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Added to the team",
+                        "schema": {
+                            "$ref": "#/definitions/database.TeamMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID format or malformed JSON",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found, or the vacancy does not belong to it",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "The vacancy has no openings left or is not open",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid team member fields",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/team/{member_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Undo a hire. If the person filled a vacancy, the opening is returned; a vacancy closed by its last hire reopens, one closed by hand stays closed. Requires the owner or manager role in the project.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Remove a person from the project team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Team member ID",
+                        "name": "member_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Removed from the team"
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Project or team member not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/vacancies": {
+            "get": {
+                "description": "Retrieve all vacancies for a given project by project ID, optionally only in the given statuses. Use sort=created_at\u0026order=desc for the newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vacancies"
+                ],
+                "summary": "Get all vacancies for a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated statuses to include, e.g. open,paused (default: all)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "name",
+                            "field",
+                            "country",
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of vacancies",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/database.Vacancy"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID format, status filter or sort",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new vacancy by providing the vacancy details and the project ID. Requires the owner or manager role in the project.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vacancies"
+                ],
+                "summary": "Create a new vacancy for a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Vacancy data (ID and ProjectID can be omitted or 0; openings defaults to 1; status may be draft or open, default open)",
+                        "name": "vacancy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/database.Vacancy"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Vacancy created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/database.Vacancy"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Vacancy version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID format or malformed JSON",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid vacancy fields",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Returns 200 when the database answers, all migrations are applied and the server is not shutting down; 503 otherwise",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "Ready to serve requests",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Not ready: see checks",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "description": "Search project and vacancy names and descriptions. Archived projects and their vacancies are excluded. Results are ordered by relevance; matched words are wrapped in \u003cmark\u003e tags.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Full-text search over projects and vacancies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "project",
+                            "vacancy"
+                        ],
+                        "type": "string",
+                        "description": "Restrict results to one entity type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search results",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing query or invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/status": {
+            "get": {
+                "description": "Version, build commit, uptime and database state (driver, size, migrations). Always returns 200; check the status field.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Detailed server status",
+                "responses": {
+                    "200": {
+                        "description": "Server status",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.StatusResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a page of the trash, most recently deleted first. Only items of projects where the user is an owner or manager are listed. Vacancies deleted together with their project are not listed separately: restoring the project restores them. purge_at tells when an item will be deleted permanently.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "List deleted projects and vacancies",
+                "parameters": [
+                    {
+                        "enum": [
+                            "project",
+                            "vacancy"
+                        ],
+                        "type": "string",
+                        "description": "Restrict results to one entity type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of deleted items",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TrashListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/vacancies": {
+            "get": {
+                "description": "Retrieve a page of vacancies from all projects with optional filters. Each item includes the name and deadline of its project. Use sort=created_at\u0026order=desc for the newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vacancies"
+                ],
+                "summary": "Search vacancies across all projects",
+                "parameters": [
+                    {
+                        "enum": [
+                            "Design",
+                            "Development",
+                            "Marketing"
+                        ],
+                        "type": "string",
+                        "description": "Filter by field",
+                        "name": "field",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by country (case-insensitive)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by exact experience value",
+                        "name": "experience",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by project ID",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated statuses to include, e.g. open,paused (default: all)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "name",
+                            "field",
+                            "country",
+                            "project_name",
+                            "deadline",
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of vacancies",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.VacancyListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/vacancies/{id}": {
+            "get": {
+                "description": "Retrieve details for a specific vacancy using its ID. The ETag header holds the vacancy version; send it in If-None-Match to get 304 while the vacancy is unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vacancies"
+                ],
+                "summary": "Get a single vacancy by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vacancy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved vacancy",
+                        "schema": {
+                            "$ref": "#/definitions/database.Vacancy"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Vacancy version, e.g. \\\"3\\"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Vacancy has not changed since the given ETag"
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Vacancy not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Edit a vacancy by ID. Requires the owner or manager role in the vacancy's project.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vacancies"
+                ],
+                "summary": "Edit an existing vacancy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vacancy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the vacancy being edited; required if the server enforces it",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Updated vacancy data (ID, ProjectID, status and version in body are ignored; openings \u003e 0 replaces the number of openings, 0 keeps it; the status does not change)",
+                        "name": "vacancy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/database.Vacancy"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vacancy updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/database.Vacancy"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New vacancy version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid vacancy ID format, malformed JSON or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the vacancy's project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Vacancy not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Vacancy changed since the ETag in If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid values in the fields the request changes",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is required but missing",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a vacancy to the trash by its ID; it can be restored with POST /vacancies/{id}/restore until the trash is purged. Requires the owner or manager role in the vacancy's project.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vacancies"
+                ],
+                "summary": "Delete a vacancy by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vacancy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the vacancy being deleted; required if the server enforces it",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Vacancy deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid vacancy ID format or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the vacancy's project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Vacancy not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Vacancy changed since the ETag in If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is required but missing",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update only the fields present in the body, following JSON Merge Patch (RFC 7396): omitted fields keep their values and null clears a field. ID, project and status cannot be changed here. Openings change only when present in the patch; as in PUT, the status does not change. Requires the owner or manager role in the vacancy's project.",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vacancies"
+                ],
+                "summary": "Partially update a vacancy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vacancy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the vacancy being edited; required if the server enforces it",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "vacancy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/database.Vacancy"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vacancy updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/database.Vacancy"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New vacancy version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid vacancy ID format, malformed patch or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the vacancy's project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Vacancy not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Vacancy changed since the ETag in If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Body is neither application/json nor application/merge-patch+json",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid values in the fields the patch changes",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is required but missing",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/vacancies/{id}/applications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Applications for one vacancy, newest first. Requires the owner or manager role in the vacancy's project.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "applications"
+                ],
+                "summary": "List applications for a vacancy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vacancy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "submitted",
+                            "reviewing",
+                            "interview",
+                            "offer",
+                            "hired",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of applications",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ApplicationListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid vacancy ID format or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the vacancy's project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Vacancy not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Submit an application with contact details and a cover letter. No account is needed; each email can apply to a vacancy once. Only open vacancies accept applications.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "applications"
+                ],
+                "summary": "Apply for a vacancy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vacancy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Applicant contact details and cover letter",
+                        "name": "application",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ApplyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Application submitted",
+                        "schema": {
+                            "$ref": "#/definitions/database.Application"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid vacancy ID format or malformed JSON",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Vacancy not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "The vacancy is not open, or this email has already applied to it",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid application fields",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/vacancies/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bring a vacancy back from the trash. If its project is in the trash too, restore the project instead. Requires the owner or manager role in the project.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore a deleted vacancy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vacancy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored vacancy",
+                        "schema": {
+                            "$ref": "#/definitions/database.Vacancy"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid vacancy ID format",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Vacancy not found in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "The vacancy's project is in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/vacancies/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a page of saved versions of the vacancy's name, description, field, country and experience, newest first. Revision 1 is the content before the first edit; every edit adds the next one. changes lists the fields that differ from the previous revision. Requires the owner or manager role in the vacancy's project.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vacancies"
+                ],
+                "summary": "List vacancy revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vacancy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of revisions",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RevisionListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid vacancy ID or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the vacancy's project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Vacancy not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/vacancies/{id}/revisions/{rev}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bring the vacancy's name, description, field, country and experience back to the given revision. Openings and status are not changed. The restore is saved as a new revision; nothing in the history is removed. Requires the owner or manager role in the vacancy's project.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vacancies"
+                ],
+                "summary": "Restore a vacancy revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vacancy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "rev",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the vacancy being changed",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vacancy with the restored content",
+                        "schema": {
+                            "$ref": "#/definitions/database.Vacancy"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New vacancy version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid vacancy ID, revision number or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the vacancy's project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Vacancy or revision not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Vacancy changed since the ETag in If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/vacancies/{id}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a vacancy through its lifecycle. Allowed changes: draft → open or closed; open → paused or closed; paused → open or closed; closed → open; expired → open or closed. Vacancies become expired automatically once their project's deadline has passed. Opening requires at least one opening and a deadline that has not passed. Only open vacancies accept applications. Requires the owner or manager role in the vacancy's project.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vacancies"
+                ],
+                "summary": "Change the status of a vacancy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vacancy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the vacancy being changed; required if the server enforces it",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "New status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ChangeVacancyStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status changed",
+                        "schema": {
+                            "$ref": "#/definitions/database.Vacancy"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New vacancy version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid vacancy ID format, malformed JSON or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired access token",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an owner or manager of the vacancy's project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Vacancy not found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Transition not allowed, no openings left or project deadline passed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.InvalidVacancyTransitionResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Vacancy changed since the ETag in If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Missing or unknown status",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is required but missing",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apierror.Code": {
+            "type": "string",
+            "enum": [
+                "malformed_request",
+                "unsupported_media_type",
+                "invalid_parameter",
+                "validation_failed",
+                "unauthorized",
+                "forbidden",
+                "precondition_failed",
+                "precondition_required",
+                "internal_error",
+                "project_not_found",
+                "project_has_vacancies",
+                "vacancy_not_found",
+                "invalid_status_transition",
+                "no_openings",
+                "vacancy_not_open",
+                "deadline_passed",
+                "project_deleted",
+                "revision_not_found",
+                "team_member_not_found",
+                "application_not_found",
+                "vacancy_not_accepting_applications",
+                "already_applied",
+                "invalid_credentials",
+                "invalid_refresh_token",
+                "email_taken",
+                "user_not_found",
+                "member_not_found",
+                "last_owner"
+            ],
+            "x-enum-comments": {
+                "CodeAlreadyApplied": "с этого email уже откликались",
+                "CodeForbidden": "не хватает роли в проекте",
+                "CodeInternal": "внутренняя ошибка сервера",
+                "CodeInvalidParameter": "неверный параметр пути или query-строки",
+                "CodeInvalidRefreshToken": "неизвестный, истекший, отозванный или повторно предъявленный",
+                "CodeLastOwner": "у проекта должен остаться хотя бы один владелец",
+                "CodeMalformedRequest": "тело запроса - некорректный JSON",
+                "CodePreconditionFailed": "запись изменилась после получения ETag из If-Match",
+                "CodePreconditionRequired": "изменение без If-Match, когда он обязателен",
+                "CodeProjectDeleted": "вакансию нельзя восстановить, пока ее проект в корзине",
+                "CodeUnauthorized": "нет действительного access-токена",
+                "CodeUnsupportedMediaType": "тело запроса в неподдерживаемом формате",
+                "CodeVacancyNotAccepting": "вакансия не в статусе open",
+                "CodeVacancyNotOpen": "нанять можно только на вакансию в статусе open",
+                "CodeValidationFailed": "поля тела запроса не прошли проверку"
+            },
+            "x-enum-varnames": [
+                "CodeMalformedRequest",
+                "CodeUnsupportedMediaType",
+                "CodeInvalidParameter",
+                "CodeValidationFailed",
+                "CodeUnauthorized",
+                "CodeForbidden",
+                "CodePreconditionFailed",
+                "CodePreconditionRequired",
+                "CodeInternal",
+                "CodeProjectNotFound",
+                "CodeProjectHasVacancies",
+                "CodeVacancyNotFound",
+                "CodeInvalidStatusTransition",
+                "CodeNoOpenings",
+                "CodeVacancyNotOpen",
+                "CodeDeadlinePassed",
+                "CodeProjectDeleted",
+                "CodeRevisionNotFound",
+                "CodeTeamMemberNotFound",
+                "CodeApplicationNotFound",
+                "CodeVacancyNotAccepting",
+                "CodeAlreadyApplied",
+                "CodeInvalidCredentials",
+                "CodeInvalidRefreshToken",
+                "CodeEmailTaken",
+                "CodeUserNotFound",
+                "CodeMemberNotFound",
+                "CodeLastOwner"
+            ]
+        },
+        "apierror.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/apierror.Code"
+                        }
+                    ],
+                    "example": "project_not_found"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "project 42 does not exist"
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/projects/42"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "8f14e45fceea167a5a36dedd4bea2543"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Project not found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "/problems/project_not_found"
+                }
+            }
+        },
+        "database.Application": {
+            "type": "object",
+            "properties": {
+                "cover_letter": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "enum": [
+                        "submitted",
+                        "reviewing",
+                        "interview",
+                        "offer",
+                        "hired",
+                        "rejected"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/database.ApplicationStatus"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vacancy_id": {
+                    "type": "integer"
+                },
+                "vacancy_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "database.ApplicationEvent": {
+            "type": "object",
+            "properties": {
+                "application_id": {
+                    "type": "integer"
+                },
+                "changed_by": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "$ref": "#/definitions/database.ApplicationStatus"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "$ref": "#/definitions/database.ApplicationStatus"
+                }
+            }
+        },
+        "database.ApplicationStatus": {
+            "type": "string",
+            "enum": [
+                "submitted",
+                "reviewing",
+                "interview",
+                "offer",
+                "hired",
+                "rejected"
+            ],
+            "x-enum-comments": {
+                "ApplicationHired": "кандидат принят",
+                "ApplicationInterview": "назначено собеседование",
+                "ApplicationOffer": "кандидату сделано предложение",
+                "ApplicationRejected": "отказ",
+                "ApplicationReviewing": "отклик рассматривается",
+                "ApplicationSubmitted": "кандидат откликнулся"
+            },
+            "x-enum-varnames": [
+                "ApplicationSubmitted",
+                "ApplicationReviewing",
+                "ApplicationInterview",
+                "ApplicationOffer",
+                "ApplicationHired",
+                "ApplicationRejected"
+            ]
+        },
+        "database.AuditAction": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "delete",
+                "archive",
+                "restore"
+            ],
+            "x-enum-comments": {
+                "AuditArchive": "архивация вместо удаления (политика \"archive\")",
+                "AuditDelete": "удаление в корзину",
+                "AuditRestore": "восстановление из корзины"
+            },
+            "x-enum-varnames": [
+                "AuditCreate",
+                "AuditUpdate",
+                "AuditDelete",
+                "AuditArchive",
+                "AuditRestore"
+            ]
+        },
+        "database.AuditEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete",
+                        "archive",
+                        "restore"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/database.AuditAction"
+                        }
+                    ]
+                },
+                "actor_id": {
+                    "description": "ActorID - кто внес изменение; пусто у изменений без пользователя (например, начальных данных)",
+                    "type": "integer"
+                },
+                "actor_name": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "integer"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "enum": [
+                        "project",
+                        "vacancy"
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "description": "ProjectID - проект записи (для проекта совпадает с EntityID)",
+                    "type": "integer"
+                },
+                "request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "database.FieldChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "deadline"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2026-11-30"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2026-12-31"
+                }
+            }
+        },
+        "database.Project": {
+            "type": "object",
+            "required": [
+                "deadline",
+                "experience",
+                "name"
+            ],
+            "properties": {
+                "archived_at": {
+                    "description": "Заполняется, когда проект архивирован вместо удаления (политика удаления \"archive\")",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string",
+                    "readOnly": true
+                },
+                "created_by": {
+                    "description": "CreatedBy и UpdatedBy пусты у изменений без пользователя (начальные данные, фоновые задачи)",
+                    "type": "integer",
+                    "readOnly": true
+                },
+                "deadline": {
+                    "description": "Deadline принимается как YYYY-MM-DD или DD.MM.YYYY, отдается как YYYY-MM-DD",
+                    "type": "string",
+                    "format": "date",
+                    "example": "2026-12-31"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt заполняется, когда проект удален в корзину",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "experience": {
+                    "type": "string",
+                    "example": "3+ years"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "owner_id": {
+                    "description": "OwnerID - создатель проекта; пусто у проектов, созданных до появления учетных записей",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "readOnly": true
+                },
+                "updated_by": {
+                    "type": "integer",
+                    "readOnly": true
+                },
+                "version": {
+                    "description": "Version увеличивается при каждом изменении; отдается в ETag и проверяется по If-Match",
+                    "type": "integer",
+                    "readOnly": true,
+                    "example": 3
+                }
+            }
+        },
+        "database.ProjectMember": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "enum": [
+                        "owner",
+                        "manager",
+                        "viewer"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/database.ProjectRole"
+                        }
+                    ]
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "database.ProjectRole": {
+            "type": "string",
+            "enum": [
+                "owner",
+                "manager",
+                "viewer"
+            ],
+            "x-enum-varnames": [
+                "RoleOwner",
+                "RoleManager",
+                "RoleViewer"
+            ]
+        },
+        "database.Revision": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "description": "AuthorID - кто внес изменение; пусто у первой ревизии и у изменений без пользователя",
+                    "type": "integer"
+                },
+                "author_name": {
+                    "type": "string"
+                },
+                "changes": {
+                    "description": "Changes - чем ревизия отличается от предыдущей; у первой ревизии пусто",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.FieldChange"
+                    }
+                },
+                "content": {
+                    "description": "Content - содержимое целиком (ProjectContent или VacancyContent)",
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "integer"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "enum": [
+                        "project",
+                        "vacancy"
+                    ]
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "revision": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "database.SearchResult": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "description": "bm25: чем меньше, тем релевантнее",
+                    "type": "number"
+                },
+                "snippet": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "project",
+                        "vacancy"
+                    ]
+                }
+            }
+        },
+        "database.TeamMember": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "vacancy_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "database.TrashItem": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "purge_at": {
+                    "description": "PurgeAt - когда запись будет удалена окончательно; пусто, если корзина не очищается",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "project",
+                        "vacancy"
+                    ]
+                }
+            }
+        },
+        "database.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "database.Vacancy": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "closed_at": {
+                    "description": "ClosedAt заполняется, когда вакансия закрывается: вручную или когда Openings доходит до нуля",
+                    "type": "string"
+                },
+                "country": {
+                    "description": "ISO 3166-1 alpha-2",
+                    "type": "string",
+                    "example": "DE"
+                },
+                "created_at": {
+                    "type": "string",
+                    "readOnly": true
+                },
+                "created_by": {
+                    "description": "CreatedBy и UpdatedBy пусты у изменений без пользователя (начальные данные, фоновые задачи)",
+                    "type": "integer",
+                    "readOnly": true
+                },
+                "deleted_at": {
+                    "description": "DeletedAt заполняется, когда вакансия (или ее проект) удалена в корзину",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Оставляем string, sqlx справится с NULL -\u003e \"\"",
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "experience": {
+                    "description": "см. handlers.experiencePattern",
+                    "type": "string",
+                    "example": "3+ years"
+                },
+                "field": {
+                    "type": "string",
+                    "enum": [
+                        "Design",
+                        "Development",
+                        "Marketing"
+                    ]
+                },
+                "id": {
+                    "description": "Для sqlx используем db тег, для JSON - json",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "openings": {
+                    "description": "Openings - сколько людей еще нужно; уменьшается, когда человека принимают в команду по вакансии",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2
+                },
+                "project_id": {
+                    "description": "Имя поля совпадает с колонкой",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status - этап жизненного цикла; отклики принимаются только в статусе open",
+                    "enum": [
+                        "draft",
+                        "open",
+                        "paused",
+                        "closed",
+                        "expired"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/database.VacancyStatus"
+                        }
+                    ],
+                    "example": "open"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "readOnly": true
+                },
+                "updated_by": {
+                    "type": "integer",
+                    "readOnly": true
+                },
+                "version": {
+                    "description": "Version увеличивается при каждом изменении; отдается в ETag и проверяется по If-Match",
+                    "type": "integer",
+                    "readOnly": true,
+                    "example": 3
+                }
+            }
+        },
+        "database.VacancyStatus": {
+            "type": "string",
+            "enum": [
+                "draft",
+                "open",
+                "paused",
+                "closed",
+                "expired"
+            ],
+            "x-enum-comments": {
+                "VacancyClosed": "набор завершен",
+                "VacancyDraft": "черновик, еще не опубликована",
+                "VacancyExpired": "дедлайн проекта прошел",
+                "VacancyOpen": "принимает отклики",
+                "VacancyPaused": "временно не принимает отклики"
+            },
+            "x-enum-varnames": [
+                "VacancyDraft",
+                "VacancyOpen",
+                "VacancyPaused",
+                "VacancyClosed",
+                "VacancyExpired"
+            ]
+        },
+        "database.VacancyWithProject": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "closed_at": {
+                    "description": "ClosedAt заполняется, когда вакансия закрывается: вручную или когда Openings доходит до нуля",
+                    "type": "string"
+                },
+                "country": {
+                    "description": "ISO 3166-1 alpha-2",
+                    "type": "string",
+                    "example": "DE"
+                },
+                "created_at": {
+                    "type": "string",
+                    "readOnly": true
+                },
+                "created_by": {
+                    "description": "CreatedBy и UpdatedBy пусты у изменений без пользователя (начальные данные, фоновые задачи)",
+                    "type": "integer",
+                    "readOnly": true
+                },
+                "deleted_at": {
+                    "description": "DeletedAt заполняется, когда вакансия (или ее проект) удалена в корзину",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Оставляем string, sqlx справится с NULL -\u003e \"\"",
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "experience": {
+                    "description": "см. handlers.experiencePattern",
+                    "type": "string",
+                    "example": "3+ years"
+                },
+                "field": {
+                    "type": "string",
+                    "enum": [
+                        "Design",
+                        "Development",
+                        "Marketing"
+                    ]
+                },
+                "id": {
+                    "description": "Для sqlx используем db тег, для JSON - json",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "openings": {
+                    "description": "Openings - сколько людей еще нужно; уменьшается, когда человека принимают в команду по вакансии",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2
+                },
+                "project_deadline": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2026-12-31"
+                },
+                "project_id": {
+                    "description": "Имя поля совпадает с колонкой",
+                    "type": "integer"
+                },
+                "project_name": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status - этап жизненного цикла; отклики принимаются только в статусе open",
+                    "enum": [
+                        "draft",
+                        "open",
+                        "paused",
+                        "closed",
+                        "expired"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/database.VacancyStatus"
+                        }
+                    ],
+                    "example": "open"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "readOnly": true
+                },
+                "updated_by": {
+                    "type": "integer",
+                    "readOnly": true
+                },
+                "version": {
+                    "description": "Version увеличивается при каждом изменении; отдается в ETag и проверяется по If-Match",
+                    "type": "integer",
+                    "readOnly": true,
+                    "example": 3
+                }
+            }
+        },
+        "handlers.AddTeamMemberRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Jamie Doe"
+                },
+                "position": {
+                    "type": "string",
+                    "example": "Product designer"
+                },
+                "vacancy_id": {
+                    "description": "VacancyID - вакансия проекта, на которую принят человек; занимает одно ее место",
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "handlers.ApplicationDetails": {
+            "type": "object",
+            "properties": {
+                "cover_letter": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.ApplicationEvent"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next_statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.ApplicationStatus"
+                    }
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "enum": [
+                        "submitted",
+                        "reviewing",
+                        "interview",
+                        "offer",
+                        "hired",
+                        "rejected"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/database.ApplicationStatus"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vacancy_id": {
+                    "type": "integer"
+                },
+                "vacancy_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ApplicationListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.Application"
+                    }
+                },
+                "limit": {
+                    "description": "Размер страницы",
+                    "type": "integer",
+                    "example": 20
+                },
+                "next": {
+                    "description": "Ссылка на следующую страницу",
+                    "type": "string",
+                    "example": "/projects?page=2"
+                },
+                "page": {
+                    "description": "Номер текущей страницы (с 1)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "pages": {
+                    "description": "Общее количество страниц",
+                    "type": "integer",
+                    "example": 3
+                },
+                "prev": {
+                    "description": "Ссылка на предыдущую страницу",
+                    "type": "string",
+                    "example": "/projects?page=1"
+                },
+                "total": {
+                    "description": "Общее количество записей, подходящих под фильтры",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "handlers.ApplyRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name"
+            ],
+            "properties": {
+                "cover_letter": {
+                    "type": "string",
+                    "example": "I have five years of product design experience..."
+                },
+                "email": {
+                    "type": "string",
+                    "example": "jamie.doe@example.com"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Jamie Doe"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+1 555 0100"
+                }
+            }
+        },
+        "handlers.AuditListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.AuditEvent"
+                    }
+                },
+                "limit": {
+                    "description": "Размер страницы",
+                    "type": "integer",
+                    "example": 20
+                },
+                "next": {
+                    "description": "Ссылка на следующую страницу",
+                    "type": "string",
+                    "example": "/projects?page=2"
+                },
+                "page": {
+                    "description": "Номер текущей страницы (с 1)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "pages": {
+                    "description": "Общее количество страниц",
+                    "type": "integer",
+                    "example": 3
+                },
+                "prev": {
+                    "description": "Ссылка на предыдущую страницу",
+                    "type": "string",
+                    "example": "/projects?page=1"
+                },
+                "total": {
+                    "description": "Общее количество записей, подходящих под фильтры",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "handlers.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "refresh_expires_at": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                },
+                "user": {
+                    "$ref": "#/definitions/database.User"
+                }
+            }
+        },
+        "handlers.ChangeStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "Strong portfolio"
+                },
+                "status": {
+                    "enum": [
+                        "submitted",
+                        "reviewing",
+                        "interview",
+                        "offer",
+                        "hired",
+                        "rejected"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/database.ApplicationStatus"
+                        }
+                    ],
+                    "example": "reviewing"
+                }
+            }
+        },
+        "handlers.ChangeVacancyStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "enum": [
+                        "draft",
+                        "open",
+                        "paused",
+                        "closed"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/database.VacancyStatus"
+                        }
+                    ],
+                    "example": "paused"
+                }
+            }
+        },
+        "handlers.DatabaseStatus": {
+            "type": "object",
+            "properties": {
+                "applied_migrations": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "driver": {
+                    "type": "string",
+                    "example": "sqlite3"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "pending_migrations": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "size_bytes": {
+                    "type": "integer",
+                    "example": 73728
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "handlers.DeleteProjectConflict": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/apierror.Code"
                         }
+                    ],
+                    "example": "project_not_found"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "project 42 does not exist"
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/projects/42"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "8f14e45fceea167a5a36dedd4bea2543"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Project not found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "/problems/project_not_found"
+                },
+                "vacancies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.BlockingVacancy"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.Project": {
+        },
+        "handlers.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "name"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "is required"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "required"
+                }
+            }
+        },
+        "handlers.InvalidTransitionResponse": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.ApplicationStatus"
+                    }
+                },
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/apierror.Code"
+                        }
+                    ],
+                    "example": "project_not_found"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "project 42 does not exist"
+                },
+                "from": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/database.ApplicationStatus"
+                        }
+                    ],
+                    "example": "submitted"
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/projects/42"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "8f14e45fceea167a5a36dedd4bea2543"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Project not found"
+                },
+                "to": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/database.ApplicationStatus"
+                        }
+                    ],
+                    "example": "hired"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "/problems/project_not_found"
+                }
+            }
+        },
+        "handlers.InvalidVacancyTransitionResponse": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.VacancyStatus"
+                    }
+                },
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/apierror.Code"
+                        }
+                    ],
+                    "example": "project_not_found"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "project 42 does not exist"
+                },
+                "from": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/database.VacancyStatus"
+                        }
+                    ],
+                    "example": "closed"
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/projects/42"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "8f14e45fceea167a5a36dedd4bea2543"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Project not found"
+                },
+                "to": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/database.VacancyStatus"
+                        }
+                    ],
+                    "example": "paused"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "/problems/project_not_found"
+                }
+            }
+        },
+        "handlers.LivenessResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "handlers.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "alex.smith@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "correct horse battery"
+                }
+            }
+        },
+        "handlers.ProjectDetails": {
             "type": "object",
+            "required": [
+                "deadline",
+                "experience",
+                "name"
+            ],
             "properties": {
+                "archived_at": {
+                    "description": "Заполняется, когда проект архивирован вместо удаления (политика удаления \"archive\")",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string",
+                    "readOnly": true
+                },
+                "created_by": {
+                    "description": "CreatedBy и UpdatedBy пусты у изменений без пользователя (начальные данные, фоновые задачи)",
+                    "type": "integer",
+                    "readOnly": true
+                },
                 "deadline": {
+                    "description": "Deadline принимается как YYYY-MM-DD или DD.MM.YYYY, отдается как YYYY-MM-DD",
+                    "type": "string",
+                    "format": "date",
+                    "example": "2026-12-31"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt заполняется, когда проект удален в корзину",
                     "type": "string"
                 },
                 "description": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 5000
                 },
                 "experience": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "3+ years"
+                },
+                "headcount": {
+                    "description": "Людей в команде",
+                    "type": "integer",
+                    "example": 3
                 },
                 "id": {
                     "type": "integer"
                 },
                 "name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "open_positions": {
+                    "description": "Свободных мест в открытых вакансиях проекта",
+                    "type": "integer",
+                    "example": 2
+                },
+                "owner_id": {
+                    "description": "OwnerID - создатель проекта; пусто у проектов, созданных до появления учетных записей",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "readOnly": true
+                },
+                "updated_by": {
+                    "type": "integer",
+                    "readOnly": true
+                },
+                "version": {
+                    "description": "Version увеличивается при каждом изменении; отдается в ETag и проверяется по If-Match",
+                    "type": "integer",
+                    "readOnly": true,
+                    "example": 3
                 }
             }
         },
-        "models.Vacancy": {
+        "handlers.ProjectListResponse": {
             "type": "object",
             "properties": {
-                "country": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.Project"
+                    }
                 },
-                "description": {
-                    "type": "string"
+                "limit": {
+                    "description": "Размер страницы",
+                    "type": "integer",
+                    "example": 20
                 },
-                "experience": {
+                "next": {
+                    "description": "Ссылка на следующую страницу",
+                    "type": "string",
+                    "example": "/projects?page=2"
+                },
+                "page": {
+                    "description": "Номер текущей страницы (с 1)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "pages": {
+                    "description": "Общее количество страниц",
+                    "type": "integer",
+                    "example": 3
+                },
+                "prev": {
+                    "description": "Ссылка на предыдущую страницу",
+                    "type": "string",
+                    "example": "/projects?page=1"
+                },
+                "total": {
+                    "description": "Общее количество записей, подходящих под фильтры",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "handlers.ReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ready"
+                }
+            }
+        },
+        "handlers.RefreshRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
                     "type": "string"
+                }
+            }
+        },
+        "handlers.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "alex.smith@example.com"
                 },
-                "field": {
+                "name": {
+                    "type": "string",
+                    "example": "Alex Smith"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "correct horse battery"
+                }
+            }
+        },
+        "handlers.RevisionListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.Revision"
+                    }
+                },
+                "limit": {
+                    "description": "Размер страницы",
+                    "type": "integer",
+                    "example": 20
+                },
+                "next": {
+                    "description": "Ссылка на следующую страницу",
+                    "type": "string",
+                    "example": "/projects?page=2"
+                },
+                "page": {
+                    "description": "Номер текущей страницы (с 1)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "pages": {
+                    "description": "Общее количество страниц",
+                    "type": "integer",
+                    "example": 3
+                },
+                "prev": {
+                    "description": "Ссылка на предыдущую страницу",
+                    "type": "string",
+                    "example": "/projects?page=1"
+                },
+                "total": {
+                    "description": "Общее количество записей, подходящих под фильтры",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "handlers.SearchResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.SearchResult"
+                    }
+                },
+                "limit": {
+                    "description": "Размер страницы",
+                    "type": "integer",
+                    "example": 20
+                },
+                "next": {
+                    "description": "Ссылка на следующую страницу",
+                    "type": "string",
+                    "example": "/projects?page=2"
+                },
+                "page": {
+                    "description": "Номер текущей страницы (с 1)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "pages": {
+                    "description": "Общее количество страниц",
+                    "type": "integer",
+                    "example": 3
+                },
+                "prev": {
+                    "description": "Ссылка на предыдущую страницу",
+                    "type": "string",
+                    "example": "/projects?page=1"
+                },
+                "total": {
+                    "description": "Общее количество записей, подходящих под фильтры",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "handlers.SetMemberRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "enum": [
+                        "owner",
+                        "manager",
+                        "viewer"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/database.ProjectRole"
+                        }
+                    ],
+                    "example": "manager"
+                }
+            }
+        },
+        "handlers.StatusResponse": {
+            "type": "object",
+            "properties": {
+                "commit": {
+                    "type": "string",
+                    "example": "3ab3d83"
+                },
+                "database": {
+                    "$ref": "#/definitions/handlers.DatabaseStatus"
+                },
+                "go_version": {
+                    "type": "string",
+                    "example": "go1.23.2"
+                },
+                "started_at": {
                     "type": "string"
                 },
+                "status": {
+                    "description": "ok, degraded или shutting_down",
+                    "type": "string",
+                    "example": "ok"
+                },
+                "uptime": {
+                    "type": "string",
+                    "example": "1h2m3s"
+                },
+                "uptime_seconds": {
+                    "type": "integer",
+                    "example": 3723
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.2.0"
+                }
+            }
+        },
+        "handlers.TeamResponse": {
+            "type": "object",
+            "properties": {
+                "headcount": {
+                    "description": "Людей в команде",
+                    "type": "integer",
+                    "example": 3
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.TeamMember"
+                    }
+                },
+                "open_positions": {
+                    "description": "Свободных мест в открытых вакансиях проекта",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "handlers.TrashListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.TrashItem"
+                    }
+                },
+                "limit": {
+                    "description": "Размер страницы",
+                    "type": "integer",
+                    "example": 20
+                },
+                "next": {
+                    "description": "Ссылка на следующую страницу",
+                    "type": "string",
+                    "example": "/projects?page=2"
+                },
+                "page": {
+                    "description": "Номер текущей страницы (с 1)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "pages": {
+                    "description": "Общее количество страниц",
+                    "type": "integer",
+                    "example": 3
+                },
+                "prev": {
+                    "description": "Ссылка на предыдущую страницу",
+                    "type": "string",
+                    "example": "/projects?page=1"
+                },
+                "total": {
+                    "description": "Общее количество записей, подходящих под фильтры",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "handlers.VacancyListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.VacancyWithProject"
+                    }
+                },
+                "limit": {
+                    "description": "Размер страницы",
+                    "type": "integer",
+                    "example": 20
+                },
+                "next": {
+                    "description": "Ссылка на следующую страницу",
+                    "type": "string",
+                    "example": "/projects?page=2"
+                },
+                "page": {
+                    "description": "Номер текущей страницы (с 1)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "pages": {
+                    "description": "Общее количество страниц",
+                    "type": "integer",
+                    "example": 3
+                },
+                "prev": {
+                    "description": "Ссылка на предыдущую страницу",
+                    "type": "string",
+                    "example": "/projects?page=1"
+                },
+                "total": {
+                    "description": "Общее количество записей, подходящих под фильтры",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "handlers.ValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/apierror.Code"
+                        }
+                    ],
+                    "example": "project_not_found"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "project 42 does not exist"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/projects/42"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "8f14e45fceea167a5a36dedd4bea2543"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Project not found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "/problems/project_not_found"
+                }
+            }
+        },
+        "repository.BlockingVacancy": {
+            "type": "object",
+            "properties": {
                 "id": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
-                },
-                "project_id": {
-                    "type": "integer"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Access token from /auth/login in the form \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/troodinc/trood-front-hackathon/apierror"
+	"github.com/troodinc/trood-front-hackathon/auth"
+	db "github.com/troodinc/trood-front-hackathon/database"
+	"github.com/troodinc/trood-front-hackathon/repository"
+)
+
+// Наборы ролей для проверок доступа
+var (
+	// editorRoles могут изменять проект и его вакансии
+	editorRoles = []db.ProjectRole{db.RoleOwner, db.RoleManager}
+	// ownerRoles могут удалять проект и управлять участниками
+	ownerRoles = []db.ProjectRole{db.RoleOwner}
+)
+
+// authorizeProject проверяет, что текущий пользователь имеет в проекте одну из ролей allowed.
+// Если нет - сам отвечает 401, 403, 404 или 500 и возвращает false.
+//
+// У проектов без владельца (начальные данные и проекты, созданные до появления
+// учетных записей) участников нет, поэтому изменять их нельзя никому, пока
+// владельца не назначат командой projects set-owner.
+func authorizeProject(c *gin.Context, members repository.MemberRepository, projectID uint, allowed ...db.ProjectRole) bool {
+	principal, ok := auth.CurrentUser(c)
+	if !ok {
+		apierror.Respond(c, apierror.CodeUnauthorized, "")
+		return false
+	}
+
+	access, err := members.GetProjectAccess(c.Request.Context(), projectID, principal.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeProjectNotFound, "")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to check project access")
+		}
+		return false
+	}
+
+	if !slices.Contains(allowed, access.Role) {
+		apierror.Respond(c, apierror.CodeForbidden, "requires project role: "+joinNames(allowed, " or "))
+		return false
+	}
+	return true
+}
+
+// auditContext - контекст запроса, изменения в котором хранилище записывает
+// в журнал аудита от имени текущего пользователя и с ID запроса
+func auditContext(c *gin.Context) context.Context {
+	actor := repository.Actor{RequestID: apierror.RequestIDFrom(c)}
+	if principal, ok := auth.CurrentUser(c); ok {
+		actor.UserID = &principal.UserID
+	}
+	return repository.WithActor(c.Request.Context(), actor)
+}
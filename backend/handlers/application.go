@@ -0,0 +1,372 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/troodinc/trood-front-hackathon/apierror"
+	"github.com/troodinc/trood-front-hackathon/auth"
+	db "github.com/troodinc/trood-front-hackathon/database"
+	"github.com/troodinc/trood-front-hackathon/repository"
+)
+
+// Ограничения на размер полей отклика
+const (
+	maxCoverLetterLength = 5000
+	maxPhoneLength       = 32
+)
+
+// ApplicationHandler - отклики кандидатов на вакансии
+// (/vacancies/:id/applications, /projects/:id/applications, /applications/:id)
+type ApplicationHandler struct {
+	Applications repository.ApplicationRepository
+	Vacancies    repository.VacancyRepository
+	Members      repository.MemberRepository
+}
+
+// NewApplicationHandler создает обработчики откликов поверх хранилища
+func NewApplicationHandler(applications repository.ApplicationRepository, vacancies repository.VacancyRepository, members repository.MemberRepository) *ApplicationHandler {
+	return &ApplicationHandler{Applications: applications, Vacancies: vacancies, Members: members}
+}
+
+// ApplyRequest - тело POST /vacancies/:id/applications
+type ApplyRequest struct {
+	Name        string `json:"name" binding:"required" example:"Jamie Doe"`
+	Email       string `json:"email" binding:"required" example:"jamie.doe@example.com"`
+	Phone       string `json:"phone" example:"+1 555 0100"`
+	CoverLetter string `json:"cover_letter" example:"I have five years of product design experience..."`
+}
+
+// ChangeStatusRequest - тело PUT /applications/:id/status
+type ChangeStatusRequest struct {
+	Status db.ApplicationStatus `json:"status" binding:"required" enums:"submitted,reviewing,interview,offer,hired,rejected" example:"reviewing"`
+	Note   string               `json:"note" example:"Strong portfolio"`
+}
+
+// ApplicationListResponse - постраничный ответ со списком откликов
+type ApplicationListResponse struct {
+	Items []db.Application `json:"items"`
+	ListMeta
+}
+
+// ApplicationDetails - отклик, его история и статусы, в которые его можно перевести
+type ApplicationDetails struct {
+	db.Application
+	NextStatuses []db.ApplicationStatus `json:"next_statuses"`
+	History      []db.ApplicationEvent  `json:"history"`
+}
+
+// InvalidTransitionResponse - тело ответа 409 при недопустимой смене статуса
+type InvalidTransitionResponse struct {
+	apierror.Problem
+	From    db.ApplicationStatus   `json:"from" example:"submitted"`
+	To      db.ApplicationStatus   `json:"to" example:"hired"`
+	Allowed []db.ApplicationStatus `json:"allowed"`
+}
+
+// Apply godoc
+// @Summary Apply for a vacancy
+// @Description Submit an application with contact details and a cover letter. No account is needed; each email can apply to a vacancy once. Only open vacancies accept applications.
+// @Tags applications
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Vacancy ID"
+// @Param application body ApplyRequest true "Applicant contact details and cover letter"
+// @Success 201 {object} database.Application "Application submitted"
+// @Failure 400 {object} apierror.Problem "Invalid vacancy ID format or malformed JSON"
+// @Failure 404 {object} apierror.Problem "Vacancy not found"
+// @Failure 409 {object} apierror.Problem "The vacancy is not open, or this email has already applied to it"
+// @Failure 422 {object} ValidationErrorResponse "Invalid application fields"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /vacancies/{id}/applications [post]
+func (h *ApplicationHandler) Apply(c *gin.Context) {
+	vacancyID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "vacancy ID must be a positive integer")
+		return
+	}
+
+	var input ApplyRequest
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	application := db.Application{
+		VacancyID:   uint(vacancyID),
+		Name:        strings.TrimSpace(input.Name),
+		Email:       normalizeEmail(input.Email),
+		Phone:       strings.TrimSpace(input.Phone),
+		CoverLetter: strings.TrimSpace(input.CoverLetter),
+	}
+	invalid := func(field, rule, reason string) {
+		respondValidationError(c, FieldError{Field: field, Rule: rule, Reason: reason})
+	}
+	switch address, err := mail.ParseAddress(application.Email); {
+	case application.Name == "":
+		invalid("name", "notblank", "must not be blank")
+		return
+	case err != nil || address.Address != application.Email:
+		invalid("email", "email", "must be a valid email address")
+		return
+	case utf8.RuneCountInString(application.Phone) > maxPhoneLength:
+		invalid("phone", "max", "must be at most "+strconv.Itoa(maxPhoneLength)+" characters")
+		return
+	case utf8.RuneCountInString(application.CoverLetter) > maxCoverLetterLength:
+		invalid("cover_letter", "max", "must be at most "+strconv.Itoa(maxCoverLetterLength)+" characters")
+		return
+	}
+
+	if err := h.Applications.CreateApplication(c.Request.Context(), &application); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			apierror.Respond(c, apierror.CodeVacancyNotFound, "")
+		case errors.Is(err, repository.ErrVacancyNotOpen):
+			apierror.Respond(c, apierror.CodeVacancyNotAccepting, "")
+		case errors.Is(err, repository.ErrAlreadyExists):
+			apierror.Respond(c, apierror.CodeAlreadyApplied, "")
+		default:
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to submit application")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, application)
+}
+
+// listApplications отвечает страницей откликов под filter, дочитав из запроса
+// пагинацию и фильтр по статусу
+func (h *ApplicationHandler) listApplications(c *gin.Context, filter repository.ApplicationFilter) {
+	params, err := parsePageParams(c)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, err.Error())
+		return
+	}
+	filter.Page = params.Window()
+
+	if raw := c.Query("status"); raw != "" {
+		filter.Status = db.ApplicationStatus(raw)
+		if !slices.Contains(db.ApplicationStatuses, filter.Status) {
+			apierror.Respond(c, apierror.CodeInvalidParameter, "status must be one of: "+joinNames(db.ApplicationStatuses, ", "))
+			return
+		}
+	}
+
+	applications, total, err := h.Applications.ListApplications(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve applications")
+		return
+	}
+
+	c.JSON(http.StatusOK, ApplicationListResponse{
+		Items:    applications,
+		ListMeta: buildListMeta(c, params, total),
+	})
+}
+
+// GetVacancyApplications godoc
+// @Summary List applications for a vacancy
+// @Description Applications for one vacancy, newest first. Requires the owner or manager role in the vacancy's project.
+// @Tags applications
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Vacancy ID"
+// @Param status query string false "Filter by status" Enums(submitted, reviewing, interview, offer, hired, rejected)
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param page query int false "Page number, starting from 1" default(1)
+// @Success 200 {object} ApplicationListResponse "Page of applications"
+// @Failure 400 {object} apierror.Problem "Invalid vacancy ID format or query parameters"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the vacancy's project"
+// @Failure 404 {object} apierror.Problem "Vacancy not found"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /vacancies/{id}/applications [get]
+func (h *ApplicationHandler) GetVacancyApplications(c *gin.Context) {
+	vacancyID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "vacancy ID must be a positive integer")
+		return
+	}
+
+	vacancy, err := h.Vacancies.GetVacancy(c.Request.Context(), uint(vacancyID))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeVacancyNotFound, "")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to fetch vacancy")
+		}
+		return
+	}
+	if !authorizeProject(c, h.Members, vacancy.ProjectID, editorRoles...) {
+		return
+	}
+
+	h.listApplications(c, repository.ApplicationFilter{VacancyID: vacancy.ID})
+}
+
+// GetProjectApplications godoc
+// @Summary List applications for a project
+// @Description Applications for all vacancies of a project, newest first. Requires the owner or manager role in the project.
+// @Tags applications
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param status query string false "Filter by status" Enums(submitted, reviewing, interview, offer, hired, rejected)
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param page query int false "Page number, starting from 1" default(1)
+// @Success 200 {object} ApplicationListResponse "Page of applications"
+// @Failure 400 {object} apierror.Problem "Invalid project ID format or query parameters"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the project"
+// @Failure 404 {object} apierror.Problem "Project not found"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /projects/{id}/applications [get]
+func (h *ApplicationHandler) GetProjectApplications(c *gin.Context) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "project ID must be a positive integer")
+		return
+	}
+	if !authorizeProject(c, h.Members, uint(projectID), editorRoles...) {
+		return
+	}
+
+	h.listApplications(c, repository.ApplicationFilter{ProjectID: uint(projectID)})
+}
+
+// authorizedApplication загружает отклик и проверяет, что текущий пользователь -
+// владелец или менеджер его проекта. При ошибке сам отвечает и возвращает false.
+func (h *ApplicationHandler) authorizedApplication(c *gin.Context) (db.Application, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "application ID must be a positive integer")
+		return db.Application{}, false
+	}
+
+	application, err := h.Applications.GetApplication(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeApplicationNotFound, "")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to fetch application")
+		}
+		return db.Application{}, false
+	}
+	if !authorizeProject(c, h.Members, application.ProjectID, editorRoles...) {
+		return db.Application{}, false
+	}
+	return application, true
+}
+
+// respondWithDetails отвечает откликом вместе с историей статусов
+func (h *ApplicationHandler) respondWithDetails(c *gin.Context, application db.Application) {
+	history, err := h.Applications.ListApplicationEvents(c.Request.Context(), application.ID)
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve application history")
+		return
+	}
+	c.JSON(http.StatusOK, ApplicationDetails{
+		Application:  application,
+		NextStatuses: application.Status.NextStatuses(),
+		History:      history,
+	})
+}
+
+// GetApplicationByID godoc
+// @Summary Get an application
+// @Description Application with its status history and the statuses it can move to next. Requires the owner or manager role in the vacancy's project.
+// @Tags applications
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Application ID"
+// @Success 200 {object} ApplicationDetails "Application with history"
+// @Failure 400 {object} apierror.Problem "Invalid application ID format"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the vacancy's project"
+// @Failure 404 {object} apierror.Problem "Application not found"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /applications/{id} [get]
+func (h *ApplicationHandler) GetApplicationByID(c *gin.Context) {
+	application, ok := h.authorizedApplication(c)
+	if !ok {
+		return
+	}
+	h.respondWithDetails(c, application)
+}
+
+// ChangeApplicationStatus godoc
+// @Summary Move an application to another status
+// @Description Applications move forward only: submitted → reviewing → interview → offer → hired, and can be rejected at any step before hiring. hired and rejected are final. Every change is recorded in the history with its author and an optional note. Requires the owner or manager role in the vacancy's project.
+// @Tags applications
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Application ID"
+// @Param status body ChangeStatusRequest true "New status and an optional note"
+// @Success 200 {object} ApplicationDetails "Status changed"
+// @Failure 400 {object} apierror.Problem "Invalid application ID format or malformed JSON"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the vacancy's project"
+// @Failure 404 {object} apierror.Problem "Application not found"
+// @Failure 409 {object} InvalidTransitionResponse "The application cannot move to this status from its current one"
+// @Failure 422 {object} ValidationErrorResponse "Missing or unknown status"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /applications/{id}/status [put]
+func (h *ApplicationHandler) ChangeApplicationStatus(c *gin.Context) {
+	var input ChangeStatusRequest
+	if !bindJSON(c, &input) {
+		return
+	}
+	if !slices.Contains(db.ApplicationStatuses, input.Status) {
+		respondValidationError(c, FieldError{Field: "status", Rule: "oneof", Reason: "must be one of: " + joinNames(db.ApplicationStatuses, ", ")})
+		return
+	}
+
+	application, ok := h.authorizedApplication(c)
+	if !ok {
+		return
+	}
+
+	event := db.ApplicationEvent{ToStatus: input.Status, Note: strings.TrimSpace(input.Note)}
+	if principal, ok := auth.CurrentUser(c); ok {
+		event.ChangedBy = &principal.UserID
+	}
+
+	application, err := h.Applications.ChangeApplicationStatus(c.Request.Context(), application.ID, &event)
+	var invalid *repository.InvalidTransitionError
+	switch {
+	case err == nil:
+		h.respondWithDetails(c, application)
+	case errors.Is(err, repository.ErrNotFound):
+		apierror.Respond(c, apierror.CodeApplicationNotFound, "")
+	case errors.As(err, &invalid):
+		apierror.Write(c, &InvalidTransitionResponse{
+			Problem: apierror.New(apierror.CodeInvalidStatusTransition, "cannot change status from "+string(invalid.From)+" to "+string(invalid.To)),
+			From:    invalid.From,
+			To:      invalid.To,
+			Allowed: invalid.From.NextStatuses(),
+		})
+	default:
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to change application status")
+	}
+}
+
+// joinNames перечисляет значения строкового типа (статусы, роли) через sep для сообщений об ошибках
+func joinNames[T ~string](values []T, sep string) string {
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = string(v)
+	}
+	return strings.Join(names, sep)
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/troodinc/trood-front-hackathon/apierror"
+	"github.com/troodinc/trood-front-hackathon/auth"
+	db "github.com/troodinc/trood-front-hackathon/database"
+	"github.com/troodinc/trood-front-hackathon/repository"
+)
+
+// AuditHandler - чтение журнала аудита изменений проектов и вакансий
+type AuditHandler struct {
+	Audit repository.AuditRepository
+}
+
+// NewAuditHandler создает обработчики журнала аудита поверх хранилища
+func NewAuditHandler(audit repository.AuditRepository) *AuditHandler {
+	return &AuditHandler{Audit: audit}
+}
+
+// AuditListResponse - постраничный ответ для GET /audit
+type AuditListResponse struct {
+	Items []db.AuditEvent `json:"items"`
+	ListMeta
+}
+
+// ListAuditEvents godoc
+// @Summary List audit events
+// @Description Retrieve a page of the audit log, newest first. Every change of a project or vacancy is recorded with its author, request ID and the changed fields before and after. Only events of projects where the user is an owner or manager are listed.
+// @Tags Audit
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param entity_type query string false "Restrict results to one entity type" Enums(project, vacancy)
+// @Param entity_id query int false "Only events of this project or vacancy (use with entity_type)"
+// @Param project_id query int false "Only events of this project and its vacancies"
+// @Param actor_id query int false "Only changes made by this user"
+// @Param action query string false "Only this kind of change" Enums(create, update, delete, archive, restore)
+// @Param since query string false "Only events at or after this time (RFC 3339)"
+// @Param until query string false "Only events before this time (RFC 3339)"
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param page query int false "Page number, starting from 1" default(1)
+// @Success 200 {object} AuditListResponse "Page of audit events"
+// @Failure 400 {object} apierror.Problem "Invalid query parameters"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /audit [get]
+func (h *AuditHandler) ListAuditEvents(c *gin.Context) {
+	principal, ok := auth.CurrentUser(c)
+	if !ok {
+		apierror.Respond(c, apierror.CodeUnauthorized, "")
+		return
+	}
+
+	params, err := parsePageParams(c)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	filter := repository.AuditFilter{
+		UserID:     principal.UserID,
+		Action:     db.AuditAction(c.Query("action")),
+		EntityType: c.Query("entity_type"),
+		Page:       params.Window(),
+	}
+	if filter.EntityType != "" && filter.EntityType != "project" && filter.EntityType != "vacancy" {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "entity_type must be 'project' or 'vacancy'")
+		return
+	}
+	if filter.Action != "" && !slices.Contains(db.AuditActions, filter.Action) {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "action must be one of: "+joinNames(db.AuditActions, ", "))
+		return
+	}
+	if filter.EntityID, err = parseIDParam(c, "entity_id"); err == nil {
+		if filter.ProjectID, err = parseIDParam(c, "project_id"); err == nil {
+			filter.ActorID, err = parseIDParam(c, "actor_id")
+		}
+	}
+	if err == nil {
+		if filter.Since, err = parseTimeParam(c, "since"); err == nil {
+			filter.Until, err = parseTimeParam(c, "until")
+		}
+	}
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	events, total, err := h.Audit.ListAuditEvents(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve audit events")
+		return
+	}
+
+	c.JSON(http.StatusOK, AuditListResponse{
+		Items:    events,
+		ListMeta: buildListMeta(c, params, total),
+	})
+}
+
+// parseIDParam разбирает необязательный положительный ID из query-строки; 0 - не задан
+func parseIDParam(c *gin.Context, name string) (uint, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("%s must be a positive integer", name)
+	}
+	return uint(id), nil
+}
+
+// parseTimeParam разбирает необязательный момент времени в формате RFC 3339 из query-строки
+func parseTimeParam(c *gin.Context, name string) (time.Time, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be a time in RFC 3339 format, e.g. 2024-05-01T12:00:00Z", name)
+	}
+	return t, nil
+}
@@ -0,0 +1,376 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/troodinc/trood-front-hackathon/apierror"
+	"github.com/troodinc/trood-front-hackathon/auth"
+	db "github.com/troodinc/trood-front-hackathon/database"
+	"github.com/troodinc/trood-front-hackathon/repository"
+)
+
+// AuthHandler - регистрация, вход, обновление токенов и выход (/auth)
+type AuthHandler struct {
+	Users  repository.UserRepository
+	Tokens *auth.TokenService
+	// RefreshTTL - срок жизни refresh-токена; каждая ротация выдает токен на новый срок
+	RefreshTTL time.Duration
+}
+
+// NewAuthHandler создает обработчики аутентификации поверх хранилища
+func NewAuthHandler(users repository.UserRepository, tokens *auth.TokenService, refreshTTL time.Duration) *AuthHandler {
+	return &AuthHandler{Users: users, Tokens: tokens, RefreshTTL: refreshTTL}
+}
+
+// RegisterRequest - тело POST /auth/register
+type RegisterRequest struct {
+	Email    string `json:"email" binding:"required" example:"alex.smith@example.com"`
+	Name     string `json:"name" binding:"required,notblank" example:"Alex Smith"`
+	Password string `json:"password" binding:"required" example:"correct horse battery"`
+}
+
+// LoginRequest - тело POST /auth/login
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required" example:"alex.smith@example.com"`
+	Password string `json:"password" binding:"required" example:"correct horse battery"`
+}
+
+// RefreshRequest - тело POST /auth/refresh и POST /auth/logout
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// AuthResponse - пользователь и выданная ему пара токенов.
+// Access-токен передается в заголовке Authorization: Bearer <token>,
+// refresh-токен - в теле POST /auth/refresh, когда access-токен истечет.
+type AuthResponse struct {
+	User             db.User   `json:"user"`
+	AccessToken      string    `json:"access_token"`
+	TokenType        string    `json:"token_type" example:"Bearer"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// normalizeEmail приводит email к виду, в котором он хранится в базе
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// newSession готовит refresh-токен пользователя. Пустой familyID начинает новое
+// семейство: его идентификатором становится хеш первого токена.
+func (h *AuthHandler) newSession(userID uint, familyID string) (string, db.Session, error) {
+	token, hash, err := auth.NewToken()
+	if err != nil {
+		return "", db.Session{}, err
+	}
+	if familyID == "" {
+		familyID = hash
+	}
+	now := time.Now().UTC()
+	return token, db.Session{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hash,
+		CreatedAt: now,
+		ExpiresAt: now.Add(h.RefreshTTL),
+	}, nil
+}
+
+// respondWithTokens выпускает access-токен и отвечает AuthResponse
+func (h *AuthHandler) respondWithTokens(c *gin.Context, status int, user db.User, refreshToken string, session db.Session) {
+	accessToken, expiresAt, err := h.Tokens.IssueAccessToken(user)
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to issue access token")
+		return
+	}
+
+	c.JSON(status, AuthResponse{
+		User:             user,
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresAt:        expiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: session.ExpiresAt,
+	})
+}
+
+// startSession начинает новое семейство refresh-токенов после регистрации или входа
+func (h *AuthHandler) startSession(c *gin.Context, status int, user db.User) {
+	refreshToken, session, err := h.newSession(user.ID, "")
+	if err == nil {
+		err = h.Users.CreateSession(c.Request.Context(), &session)
+	}
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to create session")
+		return
+	}
+
+	h.respondWithTokens(c, status, user, refreshToken, session)
+}
+
+// Register godoc
+// @Summary Register a new user
+// @Description Create an account and log in right away. The email is case-insensitive; the password must be 8-72 bytes long.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param user body RegisterRequest true "Account data"
+// @Success 201 {object} AuthResponse "Account created, tokens issued"
+// @Failure 400 {object} apierror.Problem "Malformed JSON"
+// @Failure 409 {object} apierror.Problem "Email is already registered"
+// @Failure 422 {object} ValidationErrorResponse "Invalid email, name or password"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /auth/register [post]
+func (h *AuthHandler) Register(c *gin.Context) {
+	var input RegisterRequest
+	if !bindJSON(c, &input, validEmail(&input.Email), passwordLength(&input.Password)) {
+		return
+	}
+
+	email := normalizeEmail(input.Email)
+	name := strings.TrimSpace(input.Name)
+	hash, err := auth.HashPassword(input.Password)
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to create user")
+		return
+	}
+
+	user := db.User{Email: email, Name: name, PasswordHash: hash, CreatedAt: time.Now().UTC()}
+	if err := h.Users.CreateUser(c.Request.Context(), &user); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			apierror.Respond(c, apierror.CodeEmailTaken, "")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to create user")
+		}
+		return
+	}
+
+	h.startSession(c, http.StatusCreated, user)
+}
+
+// validEmail проверяет адрес почты после нормализации. Пустой адрес
+// пропускает: о нем сообщает правило required.
+func validEmail(email *string) fieldCheck {
+	return func() *FieldError {
+		normalized := normalizeEmail(*email)
+		if normalized == "" {
+			return nil
+		}
+		if address, err := mail.ParseAddress(normalized); err == nil && address.Address == normalized {
+			return nil
+		}
+		return &FieldError{Field: "email", Rule: "email", Reason: "must be a valid email address"}
+	}
+}
+
+// passwordLength проверяет длину пароля: не короче auth.MinPasswordLength символов
+// и не длиннее auth.MaxPasswordBytes байт. Пустой пароль пропускает: о нем сообщает правило required.
+func passwordLength(password *string) fieldCheck {
+	return func() *FieldError {
+		switch {
+		case *password == "":
+			return nil
+		case utf8.RuneCountInString(*password) < auth.MinPasswordLength:
+			return &FieldError{Field: "password", Rule: "min", Reason: "must be at least " + strconv.Itoa(auth.MinPasswordLength) + " characters"}
+		case len(*password) > auth.MaxPasswordBytes:
+			return &FieldError{Field: "password", Rule: "max", Reason: "must be at most " + strconv.Itoa(auth.MaxPasswordBytes) + " bytes"}
+		}
+		return nil
+	}
+}
+
+// Login godoc
+// @Summary Log in
+// @Description Exchange email and password for an access token and a refresh token
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param credentials body LoginRequest true "Email and password"
+// @Success 200 {object} AuthResponse "Tokens issued"
+// @Failure 400 {object} apierror.Problem "Malformed JSON"
+// @Failure 401 {object} apierror.Problem "Invalid email or password"
+// @Failure 422 {object} ValidationErrorResponse "Missing email or password"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var input LoginRequest
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	user, err := h.Users.GetUserByEmail(c.Request.Context(), normalizeEmail(input.Email))
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to log in")
+		return
+	}
+	// Для несуществующего пользователя хеш пустой: CheckPassword все равно
+	// потратит время на bcrypt, и ответ будет тем же, что и при неверном пароле
+	if !auth.CheckPassword(user.PasswordHash, input.Password) {
+		apierror.Respond(c, apierror.CodeInvalidCredentials, "")
+		return
+	}
+
+	h.startSession(c, http.StatusOK, user)
+}
+
+// Refresh godoc
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new access token and a new refresh token. The old refresh token stops working (rotation); presenting it again revokes every token issued from the same login.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param token body RefreshRequest true "Refresh token"
+// @Success 200 {object} AuthResponse "New tokens issued"
+// @Failure 400 {object} apierror.Problem "Malformed JSON"
+// @Failure 401 {object} apierror.Problem "Unknown, expired, revoked or reused refresh token"
+// @Failure 422 {object} ValidationErrorResponse "Missing refresh token"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var input RefreshRequest
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	now := time.Now().UTC()
+	session, err := h.Users.GetSession(ctx, auth.HashToken(input.RefreshToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeInvalidRefreshToken, "")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to refresh token")
+		}
+		return
+	}
+
+	// Отозванный токен предъявлен снова: его могли украсть, поэтому
+	// отзываем все токены, выданные с того же входа
+	if session.RevokedAt != nil {
+		h.revokeFamily(c, session.FamilyID)
+		apierror.Respond(c, apierror.CodeInvalidRefreshToken, "refresh token has already been used")
+		return
+	}
+	if !session.ExpiresAt.After(now) {
+		apierror.Respond(c, apierror.CodeInvalidRefreshToken, "refresh token has expired")
+		return
+	}
+
+	user, err := h.Users.GetUser(ctx, session.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeInvalidRefreshToken, "")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to refresh token")
+		}
+		return
+	}
+
+	refreshToken, next, err := h.newSession(user.ID, session.FamilyID)
+	if err == nil {
+		err = h.Users.RotateSession(ctx, session.ID, &next, now)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			// Параллельный запрос успел обменять этот же токен
+			h.revokeFamily(c, session.FamilyID)
+			apierror.Respond(c, apierror.CodeInvalidRefreshToken, "refresh token has already been used")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to refresh token")
+		}
+		return
+	}
+
+	h.respondWithTokens(c, http.StatusOK, user, refreshToken, next)
+}
+
+// revokeFamily отзывает семейство refresh-токенов; ошибка только логируется,
+// клиент в любом случае получает 401
+func (h *AuthHandler) revokeFamily(c *gin.Context, familyID string) {
+	if err := h.Users.RevokeSessionFamily(c.Request.Context(), familyID, time.Now().UTC()); err != nil {
+		c.Error(err)
+	}
+}
+
+// Me godoc
+// @Summary Current user
+// @Description Return the user that owns the access token
+// @Tags auth
+// @Produce  json
+// @Security BearerAuth
+// @Success 200 {object} database.User "Authenticated user"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired token"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /auth/me [get]
+func (h *AuthHandler) Me(c *gin.Context) {
+	principal, _ := auth.CurrentUser(c)
+
+	user, err := h.Users.GetUser(c.Request.Context(), principal.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeUnauthorized, "user no longer exists")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve user")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// Logout godoc
+// @Summary Log out
+// @Description Revoke the refresh token and every token rotated from the same login. Access tokens already issued stay valid until they expire.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param token body RefreshRequest true "Refresh token"
+// @Success 204 "Tokens revoked"
+// @Failure 400 {object} apierror.Problem "Malformed JSON"
+// @Failure 401 {object} apierror.Problem "Unknown refresh token"
+// @Failure 422 {object} ValidationErrorResponse "Missing refresh token"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var input RefreshRequest
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	session, err := h.Users.GetSession(ctx, auth.HashToken(input.RefreshToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeInvalidRefreshToken, "")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to log out")
+		}
+		return
+	}
+
+	if err := h.Users.RevokeSessionFamily(ctx, session.FamilyID, time.Now().UTC()); err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to log out")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
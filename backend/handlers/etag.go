@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/troodinc/trood-front-hackathon/apierror"
+)
+
+// etag возвращает ETag проекта или вакансии - версию записи в кавычках, например "3".
+// Версия растет при каждом изменении записи (см. db.Project.Version).
+func etag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// setETag добавляет к ответу заголовок ETag с версией записи
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", etag(version))
+}
+
+// notModified обрабатывает условный GET: если ETag из If-None-Match совпадает с текущей
+// версией, отвечает 304 без тела и возвращает true. ETag сравниваются без учета W/.
+func notModified(c *gin.Context, version int) bool {
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+	current := etag(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == current {
+			c.Header("ETag", current)
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersion читает из If-Match версию, которую клиент ожидает изменить; 0 - заголовка
+// нет или в нем "*" (подходит любая версия). Для некорректного заголовка сам отвечает 400
+// и возвращает false.
+func ifMatchVersion(c *gin.Context) (int, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+	// If-Match сравнивает ETag строго, поэтому слабые W/"..." не принимаем
+	raw, err := strconv.Unquote(header)
+	version, convErr := strconv.Atoi(raw)
+	if err != nil || convErr != nil || version < 1 {
+		apierror.Respond(c, apierror.CodeInvalidParameter, `If-Match must be a single ETag from a previous response, e.g. "3"`)
+		return 0, false
+	}
+	return version, true
+}
+
+// respondPreconditionFailed отвечает 412 на repository.ErrVersionMismatch
+func respondPreconditionFailed(c *gin.Context) {
+	apierror.Respond(c, apierror.CodePreconditionFailed, "the record was changed by someone else; fetch it again and retry")
+}
+
+// IfMatchPolicy возвращает middleware для PUT, PATCH и DELETE проектов и вакансий.
+// Если required, запросы без If-Match отклоняются с 428, чтобы клиент не мог
+// случайно перезаписать чужие изменения; иначе If-Match необязателен.
+func IfMatchPolicy(required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if required && c.GetHeader("If-Match") == "" {
+			apierror.Abort(c, apierror.CodePreconditionRequired, "send If-Match with the ETag of the record you are changing")
+			return
+		}
+		c.Next()
+	}
+}
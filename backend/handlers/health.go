@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// checkTimeout - сколько ждать ответа базы в проверках состояния
+const checkTimeout = 2 * time.Second
+
+// BuildInfo - версия сборки, задается через -ldflags при компиляции
+type BuildInfo struct {
+	Version string `json:"version" example:"1.2.0"`
+	Commit  string `json:"commit" example:"3ab3d83"`
+}
+
+// HealthHandler - служебные эндпоинты для Docker и оркестраторов
+type HealthHandler struct {
+	Build        BuildInfo
+	startedAt    time.Time
+	shuttingDown atomic.Bool
+}
+
+// NewHealthHandler создает обработчик проверок состояния; время старта
+// сервера отсчитывается от момента создания
+func NewHealthHandler(build BuildInfo) *HealthHandler {
+	return &HealthHandler{Build: build, startedAt: time.Now()}
+}
+
+// SetShuttingDown переводит сервер в состояние "не готов": вызывается
+// в начале остановки, чтобы балансировщик перестал присылать новые запросы
+func (h *HealthHandler) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+// LivenessResponse - ответ /healthz
+type LivenessResponse struct {
+	Status string `json:"status" example:"ok"`
+}
+
+// Healthz godoc
+// @Summary Liveness probe
+// @Description Returns 200 as long as the process is running and serving HTTP. Does not touch the database.
+// @Tags health
+// @Produce  json
+// @Success 200 {object} LivenessResponse "Process is alive"
+// @Router /healthz [get]
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, LivenessResponse{Status: "ok"})
+}
+
+// ReadinessResponse - ответ /readyz. Checks содержит "ok" или текст ошибки для каждой проверки.
+type ReadinessResponse struct {
+	Status string            `json:"status" example:"ready"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Readyz godoc
+// @Summary Readiness probe
+// @Description Returns 200 when the database answers, all migrations are applied and the server is not shutting down; 503 otherwise
+// @Tags health
+// @Produce  json
+// @Success 200 {object} ReadinessResponse "Ready to serve requests"
+// @Failure 503 {object} ReadinessResponse "Not ready: see checks"
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), checkTimeout)
+	defer cancel()
+
+	checks := map[string]string{"shutdown": "ok", "database": "ok", "migrations": "ok"}
+	ready := true
+	fail := func(name, message string) {
+		checks[name] = message
+		ready = false
+	}
+
+	if h.shuttingDown.Load() {
+		fail("shutdown", "server is shutting down")
+	}
+	if err := db.Ping(ctx); err != nil {
+		fail("database", err.Error())
+		fail("migrations", "skipped: database unavailable")
+	} else if _, pending, err := db.PendingMigrations(); err != nil {
+		fail("migrations", err.Error())
+	} else if pending > 0 {
+		fail("migrations", fmt.Sprintf("%d pending migration(s)", pending))
+	}
+
+	if !ready {
+		c.JSON(http.StatusServiceUnavailable, ReadinessResponse{Status: "not_ready", Checks: checks})
+		return
+	}
+	c.JSON(http.StatusOK, ReadinessResponse{Status: "ready", Checks: checks})
+}
+
+// DatabaseStatus - состояние базы в ответе /status
+type DatabaseStatus struct {
+	Driver            string `json:"driver" example:"sqlite3"`
+	Status            string `json:"status" example:"ok"`
+	Error             string `json:"error,omitempty"`
+	SizeBytes         int64  `json:"size_bytes" example:"73728"`
+	AppliedMigrations int    `json:"applied_migrations" example:"3"`
+	PendingMigrations int    `json:"pending_migrations" example:"0"`
+}
+
+// StatusResponse - подробный ответ /status
+type StatusResponse struct {
+	Status string `json:"status" example:"ok"` // ok, degraded или shutting_down
+	BuildInfo
+	GoVersion     string         `json:"go_version" example:"go1.23.2"`
+	StartedAt     time.Time      `json:"started_at"`
+	Uptime        string         `json:"uptime" example:"1h2m3s"`
+	UptimeSeconds int64          `json:"uptime_seconds" example:"3723"`
+	Database      DatabaseStatus `json:"database"`
+}
+
+// Status godoc
+// @Summary Detailed server status
+// @Description Version, build commit, uptime and database state (driver, size, migrations). Always returns 200; check the status field.
+// @Tags health
+// @Produce  json
+// @Success 200 {object} StatusResponse "Server status"
+// @Router /status [get]
+func (h *HealthHandler) Status(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), checkTimeout)
+	defer cancel()
+
+	uptime := time.Since(h.startedAt)
+	response := StatusResponse{
+		Status:        "ok",
+		BuildInfo:     h.Build,
+		GoVersion:     runtime.Version(),
+		StartedAt:     h.startedAt.UTC(),
+		Uptime:        uptime.Round(time.Second).String(),
+		UptimeSeconds: int64(uptime.Seconds()),
+		Database:      DatabaseStatus{Driver: db.DB.DriverName(), Status: "ok"},
+	}
+
+	var err error
+	if err = db.Ping(ctx); err == nil {
+		if response.Database.SizeBytes, err = db.Size(ctx); err == nil {
+			response.Database.AppliedMigrations, response.Database.PendingMigrations, err = db.PendingMigrations()
+		}
+	}
+	if err != nil {
+		response.Status = "degraded"
+		response.Database.Status = "error"
+		response.Database.Error = err.Error()
+	} else if response.Database.PendingMigrations > 0 {
+		response.Status = "degraded"
+	}
+	if h.shuttingDown.Load() {
+		response.Status = "shutting_down"
+	}
+
+	c.JSON(http.StatusOK, response)
+}
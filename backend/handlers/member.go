@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/troodinc/trood-front-hackathon/apierror"
+	db "github.com/troodinc/trood-front-hackathon/database"
+	"github.com/troodinc/trood-front-hackathon/repository"
+)
+
+// MemberHandler - участники проекта и их роли (/projects/:id/members)
+type MemberHandler struct {
+	Members repository.MemberRepository
+}
+
+// NewMemberHandler создает обработчики участников поверх хранилища
+func NewMemberHandler(members repository.MemberRepository) *MemberHandler {
+	return &MemberHandler{Members: members}
+}
+
+// SetMemberRequest - тело PUT /projects/:id/members/:user_id
+type SetMemberRequest struct {
+	Role db.ProjectRole `json:"role" binding:"required" enums:"owner,manager,viewer" example:"manager"`
+}
+
+// memberParams разбирает id проекта и пользователя из пути; при ошибке отвечает 400
+func memberParams(c *gin.Context) (projectID, userID uint, ok bool) {
+	pid, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "project ID must be a positive integer")
+		return 0, 0, false
+	}
+	uid, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "user ID must be a positive integer")
+		return 0, 0, false
+	}
+	return uint(pid), uint(uid), true
+}
+
+// isLastOwner сообщает, что userID - единственный владелец проекта.
+// Такого участника нельзя удалить или понизить: проект остался бы без владельца.
+func isLastOwner(members []db.ProjectMember, userID uint) bool {
+	owners := 0
+	isOwner := false
+	for _, m := range members {
+		if m.Role == db.RoleOwner {
+			owners++
+			isOwner = isOwner || m.UserID == userID
+		}
+	}
+	return isOwner && owners == 1
+}
+
+// GetMembers godoc
+// @Summary List project members
+// @Description List members of a project with their roles. Available to any member of the project.
+// @Tags members
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Success 200 {array} database.ProjectMember "Project members, owners first"
+// @Failure 400 {object} apierror.Problem "Invalid project ID format"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not a member of the project"
+// @Failure 404 {object} apierror.Problem "Project not found"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /projects/{id}/members [get]
+func (h *MemberHandler) GetMembers(c *gin.Context) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "project ID must be a positive integer")
+		return
+	}
+	if !authorizeProject(c, h.Members, uint(projectID), db.ProjectRoles...) {
+		return
+	}
+
+	members, err := h.Members.ListProjectMembers(c.Request.Context(), uint(projectID))
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve members")
+		return
+	}
+	c.JSON(http.StatusOK, members)
+}
+
+// SetMember godoc
+// @Summary Add a member or change their role
+// @Description Give a user the owner, manager or viewer role in the project. Only project owners can manage members. The last owner cannot be demoted.
+// @Tags members
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param user_id path int true "User ID"
+// @Param member body SetMemberRequest true "Role"
+// @Success 200 {array} database.ProjectMember "Updated list of project members"
+// @Failure 400 {object} apierror.Problem "Invalid ID format or malformed JSON"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner of the project"
+// @Failure 404 {object} apierror.Problem "Project or user not found"
+// @Failure 409 {object} apierror.Problem "Cannot demote the last owner"
+// @Failure 422 {object} ValidationErrorResponse "Missing or unknown role"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /projects/{id}/members/{user_id} [put]
+func (h *MemberHandler) SetMember(c *gin.Context) {
+	projectID, userID, ok := memberParams(c)
+	if !ok {
+		return
+	}
+
+	var input SetMemberRequest
+	if !bindJSON(c, &input) {
+		return
+	}
+	if !slices.Contains(db.ProjectRoles, input.Role) {
+		respondValidationError(c, FieldError{Field: "role", Rule: "oneof", Reason: "must be one of: " + joinNames(db.ProjectRoles, ", ")})
+		return
+	}
+
+	if !authorizeProject(c, h.Members, projectID, ownerRoles...) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	members, err := h.Members.ListProjectMembers(ctx, projectID)
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to update member")
+		return
+	}
+	if input.Role != db.RoleOwner && isLastOwner(members, userID) {
+		apierror.Respond(c, apierror.CodeLastOwner, "cannot demote the last owner")
+		return
+	}
+
+	if err := h.Members.SetProjectMember(ctx, projectID, userID, input.Role); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeUserNotFound, "")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to update member")
+		}
+		return
+	}
+
+	members, err = h.Members.ListProjectMembers(ctx, projectID)
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve members")
+		return
+	}
+	c.JSON(http.StatusOK, members)
+}
+
+// RemoveMember godoc
+// @Summary Remove a project member
+// @Description Take away the user's role in the project. Only project owners can manage members. The last owner cannot be removed.
+// @Tags members
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param user_id path int true "User ID"
+// @Success 204 "Member removed"
+// @Failure 400 {object} apierror.Problem "Invalid ID format"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner of the project"
+// @Failure 404 {object} apierror.Problem "Project or member not found"
+// @Failure 409 {object} apierror.Problem "Cannot remove the last owner"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /projects/{id}/members/{user_id} [delete]
+func (h *MemberHandler) RemoveMember(c *gin.Context) {
+	projectID, userID, ok := memberParams(c)
+	if !ok {
+		return
+	}
+	if !authorizeProject(c, h.Members, projectID, ownerRoles...) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	members, err := h.Members.ListProjectMembers(ctx, projectID)
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to remove member")
+		return
+	}
+	if isLastOwner(members, userID) {
+		apierror.Respond(c, apierror.CodeLastOwner, "cannot remove the last owner")
+		return
+	}
+
+	if err := h.Members.RemoveProjectMember(ctx, projectID, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeMemberNotFound, "")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to remove member")
+		}
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
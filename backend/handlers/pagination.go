@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/troodinc/trood-front-hackathon/database"
+	"github.com/troodinc/trood-front-hackathon/repository"
+)
+
+// Ограничения на размер страницы
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// ListMeta - метаданные постраничного ответа
+type ListMeta struct {
+	Total int    `json:"total" example:"42"`                        // Общее количество записей, подходящих под фильтры
+	Limit int    `json:"limit" example:"20"`                        // Размер страницы
+	Page  int    `json:"page" example:"1"`                          // Номер текущей страницы (с 1)
+	Pages int    `json:"pages" example:"3"`                         // Общее количество страниц
+	Next  string `json:"next,omitempty" example:"/projects?page=2"` // Ссылка на следующую страницу
+	Prev  string `json:"prev,omitempty" example:"/projects?page=1"` // Ссылка на предыдущую страницу
+}
+
+// pageParams - разобранные параметры limit/page из query-строки
+type pageParams struct {
+	Limit int
+	Page  int
+}
+
+// Window переводит номер страницы в limit/offset для хранилища
+func (p pageParams) Window() repository.Page {
+	return repository.Page{Limit: p.Limit, Offset: (p.Page - 1) * p.Limit}
+}
+
+// parsePageParams читает limit и page из query-строки, подставляя значения по умолчанию
+func parsePageParams(c *gin.Context) (pageParams, error) {
+	params := pageParams{Limit: defaultPageLimit, Page: 1}
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxPageLimit {
+			return params, fmt.Errorf("limit must be an integer between 1 and %d", maxPageLimit)
+		}
+		params.Limit = limit
+	}
+
+	if raw := c.Query("page"); raw != "" {
+		page, err := strconv.Atoi(raw)
+		if err != nil || page < 1 {
+			return params, fmt.Errorf("page must be a positive integer")
+		}
+		// Иначе смещение (page-1)*limit в Window переполнит int
+		if maxPage := math.MaxInt / params.Limit; page > maxPage {
+			return params, fmt.Errorf("page must be at most %d for limit %d", maxPage, params.Limit)
+		}
+		params.Page = page
+	}
+
+	return params, nil
+}
+
+// parseSortParams читает sort и order из query-строки.
+// allowed - список полей, по которым хранилище умеет сортировать.
+func parseSortParams(c *gin.Context, allowed []string, defaultSort string) (repository.Sort, error) {
+	sortField := c.DefaultQuery("sort", defaultSort)
+	known := false
+	for _, name := range allowed {
+		if name == sortField {
+			known = true
+			break
+		}
+	}
+	if !known {
+		names := append([]string(nil), allowed...)
+		sort.Strings(names)
+		return repository.Sort{}, fmt.Errorf("sort must be one of: %s", strings.Join(names, ", "))
+	}
+
+	direction := strings.ToLower(c.DefaultQuery("order", "asc"))
+	if direction != "asc" && direction != "desc" {
+		return repository.Sort{}, fmt.Errorf("order must be 'asc' or 'desc'")
+	}
+
+	return repository.Sort{Field: sortField, Desc: direction == "desc"}, nil
+}
+
+// parseDateParam разбирает дату из query-строки.
+// Принимаем ISO (YYYY-MM-DD) и формат DD.MM.YYYY, как и в теле запросов.
+func parseDateParam(c *gin.Context, name string) (db.Date, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return db.Date{}, nil
+	}
+	date, err := db.ParseDate(raw)
+	if err != nil {
+		return db.Date{}, fmt.Errorf("%s must be a date in YYYY-MM-DD or DD.MM.YYYY format", name)
+	}
+	return date, nil
+}
+
+// buildListMeta считает количество страниц и строит ссылки на соседние страницы,
+// сохраняя остальные параметры запроса (фильтры, сортировку)
+func buildListMeta(c *gin.Context, params pageParams, total int) ListMeta {
+	pages := (total + params.Limit - 1) / params.Limit
+	meta := ListMeta{
+		Total: total,
+		Limit: params.Limit,
+		Page:  params.Page,
+		Pages: pages,
+	}
+
+	pageLink := func(page int) string {
+		query := c.Request.URL.Query()
+		query.Set("page", strconv.Itoa(page))
+		query.Set("limit", strconv.Itoa(params.Limit))
+		link := url.URL{Path: c.Request.URL.Path, RawQuery: query.Encode()}
+		return link.String()
+	}
+
+	if params.Page < pages {
+		meta.Next = pageLink(params.Page + 1)
+	}
+	if params.Page > 1 && pages > 0 {
+		prev := params.Page - 1
+		if prev > pages {
+			prev = pages
+		}
+		meta.Prev = pageLink(prev)
+	}
+	return meta
+}
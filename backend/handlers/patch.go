@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/troodinc/trood-front-hackathon/apierror"
+)
+
+// mergePatchContentType - тип содержимого JSON Merge Patch (RFC 7396).
+// Принимаем и обычный application/json: фронтенд отправляет его по умолчанию.
+const mergePatchContentType = "application/merge-patch+json"
+
+// bindMergePatch применяет JSON Merge Patch из тела запроса к current и записывает
+// результат в dest, проверяя правила из тегов binding так же, как bindUpdateJSON.
+// Поля, которых нет в patch, остаются как в current; null удаляет поле (сбрасывает
+// его в нулевое значение). Возвращает сам patch, чтобы обработчик мог проверить,
+// какие поля переданы, и false, если ответ уже отправлен.
+func bindMergePatch(c *gin.Context, current, dest any) (map[string]any, bool) {
+	if mediaType, _, _ := mime.ParseMediaType(c.ContentType()); mediaType != mergePatchContentType && mediaType != "application/json" {
+		apierror.Respond(c, apierror.CodeUnsupportedMediaType, "use "+mergePatchContentType)
+		return nil, false
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeMalformedRequest, err.Error())
+		return nil, false
+	}
+	var patch map[string]any
+	if err := decodeJSON(body, &patch); err != nil || patch == nil {
+		apierror.Respond(c, apierror.CodeMalformedRequest, "merge patch must be a JSON object")
+		return nil, false
+	}
+
+	// Текущее состояние в виде JSON-документа, к которому применяется patch
+	encoded, err := json.Marshal(current)
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to apply patch")
+		return nil, false
+	}
+	var document map[string]any
+	if err := decodeJSON(encoded, &document); err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to apply patch")
+		return nil, false
+	}
+	merged, err := json.Marshal(mergePatch(document, patch))
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to apply patch")
+		return nil, false
+	}
+
+	if err := json.Unmarshal(merged, dest); err != nil {
+		respondBindError(c, body, dest, err)
+		return nil, false
+	}
+	// Как и в PUT, нарушения правил в полях, которые патч не изменил, ошибкой не считаются
+	if err := binding.Validator.ValidateStruct(dest); err != nil {
+		var validationErrs validator.ValidationErrors
+		if !errors.As(err, &validationErrs) {
+			respondBindError(c, body, dest, err)
+			return nil, false
+		}
+		if fields := changedFieldErrors(current, dest, ruleFieldErrors(validationErrs)); len(fields) > 0 {
+			respondValidationError(c, fields...)
+			return nil, false
+		}
+	}
+	return patch, true
+}
+
+// mergePatch применяет patch к target по алгоритму RFC 7396
+func mergePatch(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+		} else {
+			targetObject[key] = mergePatch(targetObject[key], value)
+		}
+	}
+	return targetObject
+}
+
+// decodeJSON разбирает JSON, сохраняя числа как json.Number, чтобы они не теряли точность
+func decodeJSON(data []byte, dest any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(dest)
+}
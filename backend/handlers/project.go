@@ -1,33 +1,42 @@
 package handlers
 
 import (
-	"database/sql" // Добавляем для sql.ErrNoRows
-	"log"          // Оставляем для InitProjects, если она будет писать в БД
+	"context"
+	"errors"
+	"log"
 	"net/http"
 	"strconv"
-
-	// "sync" // Удаляем
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/troodinc/trood-front-hackathon/apierror"
+	"github.com/troodinc/trood-front-hackathon/auth"
 	db "github.com/troodinc/trood-front-hackathon/database" // Импортируем пакет database как db
-	// "github.com/troodinc/trood-front-hackathon/models" // Удаляем
+	"github.com/troodinc/trood-front-hackathon/repository"
 )
 
-// --- УДАЛЯЕМ ГЛОБАЛЬНЫЕ ПЕРЕМЕННЫЕ ---
-/*
-var (
-	projects      = make(map[int]models.Project)
-	nextProjectID = 1
-	projectMutex  sync.Mutex
-)
-*/
-
-// InitProjects - Инициализирует проекты, теперь записывает в БД, если проектов нет
-// Важно: Эту функцию нужно вызывать ПОСЛЕ db.InitDatabase()
-func InitProjects() {
-	// Проверим, есть ли уже проекты в БД
-	var count int
-	err := db.DB.Get(&count, "SELECT COUNT(*) FROM projects")
+// ProjectHandler - обработчики маршрутов /projects
+type ProjectHandler struct {
+	Projects repository.ProjectRepository
+	Members  repository.MemberRepository
+	Team     repository.TeamRepository
+	// DeletePolicy - что делать с вакансиями при удалении проекта
+	DeletePolicy repository.DeletePolicy
+}
+
+// NewProjectHandler создает обработчики проектов поверх хранилища
+func NewProjectHandler(projects repository.ProjectRepository, members repository.MemberRepository, team repository.TeamRepository, policy repository.DeletePolicy) *ProjectHandler {
+	return &ProjectHandler{Projects: projects, Members: members, Team: team, DeletePolicy: policy}
+}
+
+// InitProjects - Инициализирует проекты, записывает начальные данные в хранилище, если проектов нет
+// Важно: для SQL-хранилища эту функцию нужно вызывать ПОСЛЕ db.InitDatabase()
+func InitProjects(projects repository.ProjectRepository) {
+	ctx := context.Background()
+
+	// Проверим, есть ли уже проекты (включая архивные и удаленные в корзину):
+	// иначе после удаления всех проектов демо-данные появились бы снова
+	count, err := projects.CountProjects(ctx)
 	if err != nil {
 		log.Printf("Warning: Could not check existing projects count: %v. Skipping initialization.", err)
 		return // Не можем проверить, лучше не инициализировать
@@ -40,456 +49,386 @@ func InitProjects() {
 
 	// Если проектов нет, добавляем начальные данные
 	log.Println("Initializing projects table with sample data...")
-	initialProjects := []db.Project{ // Используем db.Project
-		// ID можно не указывать, т.к. AUTOINCREMENT
-		{Name: "Project Alpha", Description: "A cutting-edge AI project", Deadline: "31.12.2025", Experience: "5+ years"},
-		{Name: "Project Beta", Description: "Next-gen cloud platform", Deadline: "30.06.2025", Experience: "3+ years"},
-		{Name: "Project Gamma", Description: "Blockchain-based fintech solution", Deadline: "15.09.2025", Experience: "4+ years"},
-	}
-
-	// Используем транзакцию для вставки нескольких записей
-	tx, err := db.DB.Beginx()
-	if err != nil {
-		log.Printf("Warning: Failed to begin transaction for project initialization: %v", err)
-		return
+	initialProjects := []db.Project{
+		// ID можно не указывать, его назначит хранилище
+		{Name: "Project Alpha", Description: "A cutting-edge AI project", Deadline: db.NewDate(2025, time.December, 31), Experience: "5+ years"},
+		{Name: "Project Beta", Description: "Next-gen cloud platform", Deadline: db.NewDate(2025, time.June, 30), Experience: "3+ years"},
+		{Name: "Project Gamma", Description: "Blockchain-based fintech solution", Deadline: db.NewDate(2025, time.September, 15), Experience: "4+ years"},
 	}
-	defer tx.Rollback() // Откатываем, если что-то пойдет не так
 
-	query := `INSERT INTO projects (name, description, deadline, experience) VALUES (?, ?, ?, ?)`
-	for _, p := range initialProjects {
-		_, err := tx.Exec(query, p.Name, p.Description, p.Deadline, p.Experience)
-		if err != nil {
-			log.Printf("Warning: Failed to insert initial project '%s': %v", p.Name, err)
+	for i := range initialProjects {
+		if err := projects.CreateProject(ctx, &initialProjects[i]); err != nil {
+			log.Printf("Warning: Failed to insert initial project '%s': %v", initialProjects[i].Name, err)
 			// Не прерываемся, пытаемся вставить остальные
 		}
 	}
+	log.Println("Initialized projects with sample data")
+}
 
-	if err := tx.Commit(); err != nil {
-		log.Printf("Warning: Failed to commit transaction for project initialization: %v", err)
-	} else {
-		log.Println("Initialized projects with sample data")
-	}
+// ProjectDetails - проект вместе с численностью команды и числом открытых мест
+type ProjectDetails struct {
+	db.Project
+	db.ProjectStaffing
 }
 
 // GetProjectByID godoc
 // @Summary Get a project by ID
-// @Description Retrieve a project by its ID
+// @Description Retrieve a project by its ID, with its team headcount and the number of open positions across its vacancies. The ETag header holds the project version; send it in If-None-Match to get 304 while the project is unchanged. Headcount and open positions are not part of the version.
 // @Tags Projects
 // @Accept  json
 // @Produce  json
 // @Param id path int true "Project ID"
-// @Success 200 {object} database.Project "Successfully retrieved project" // <-- Используем db.Project
-// @Failure 400 {object} map[string]string "Invalid project ID format"
-// @Failure 404 {object} map[string]string "Project not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Param If-None-Match header string false "ETag from a previous response"
+// @Success 200 {object} ProjectDetails "Successfully retrieved project"
+// @Header 200 {string} ETag "Project version, e.g. \"3\""
+// @Success 304 "Project has not changed since the given ETag"
+// @Failure 400 {object} apierror.Problem "Invalid project ID format"
+// @Failure 404 {object} apierror.Problem "Project not found"
+// @Failure 500 {object} apierror.Problem "Internal server error"
 // @Router /projects/{id} [get]
-func GetProjectByID(c *gin.Context) {
+func (h *ProjectHandler) GetProjectByID(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 64) // Используем ParseUint
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		apierror.Respond(c, apierror.CodeInvalidParameter, "project ID must be a positive integer")
 		return
 	}
 
-	var project db.Project // <-- Используем db.Project
-	query := "SELECT id, name, description, deadline, experience FROM projects WHERE id = ?"
-	err = db.DB.Get(&project, query, uint(id)) // <-- Используем db.DB.Get
-
+	project, err := h.Projects.GetProject(c.Request.Context(), uint(id))
 	if err != nil {
-		if err == sql.ErrNoRows {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeProjectNotFound, "")
 		} else {
 			c.Error(err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve project"})
+			apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve project")
 		}
 		return
 	}
+	if notModified(c, project.Version) {
+		return
+	}
+
+	staffing, err := h.Team.GetProjectStaffing(c.Request.Context(), project.ID)
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve project")
+		return
+	}
+
+	setETag(c, project.Version)
+	c.JSON(http.StatusOK, ProjectDetails{Project: project, ProjectStaffing: staffing})
+}
 
-	c.JSON(http.StatusOK, project)
+// ProjectListResponse - постраничный ответ для GET /projects
+type ProjectListResponse struct {
+	Items []db.Project `json:"items"`
+	ListMeta
 }
 
 // GetProjects godoc
-// @Summary Get all projects
-// @Description Retrieve all projects
+// @Summary Get projects
+// @Description Retrieve a page of projects with optional sorting and filtering. Use sort=created_at&order=desc for the newest first.
 // @Tags Projects
 // @Accept  json
 // @Produce  json
-// @Success 200 {array} database.Project "List of projects" // <-- Используем db.Project
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param page query int false "Page number, starting from 1" default(1)
+// @Param sort query string false "Sort field" Enums(id, name, deadline, created_at, updated_at) default(id)
+// @Param order query string false "Sort direction" Enums(asc, desc) default(asc)
+// @Param experience query string false "Filter by exact experience value, e.g. 3+ years"
+// @Param deadline_after query string false "Only projects with deadline on or after this date (YYYY-MM-DD or DD.MM.YYYY)"
+// @Param deadline_before query string false "Only projects with deadline on or before this date (YYYY-MM-DD or DD.MM.YYYY)"
+// @Param include_archived query bool false "Include archived projects" default(false)
+// @Success 200 {object} ProjectListResponse "Page of projects"
+// @Failure 400 {object} apierror.Problem "Invalid query parameters"
+// @Failure 500 {object} apierror.Problem "Internal server error"
 // @Router /projects [get]
-func GetProjects(c *gin.Context) {
-	var projectList []db.Project // <-- Используем db.Project
-	query := "SELECT id, name, description, deadline, experience FROM projects"
-	err := db.DB.Select(&projectList, query) // <-- Используем db.DB.Select
+func (h *ProjectHandler) GetProjects(c *gin.Context) {
+	params, err := parsePageParams(c)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	sort, err := parseSortParams(c, repository.ProjectSortFields, "id")
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, err.Error())
+		return
+	}
 
+	// Собираем фильтры
+	filter := repository.ProjectFilter{
+		Experience:      c.Query("experience"),
+		IncludeArchived: c.Query("include_archived") == "true",
+		Sort:            sort,
+		Page:            params.Window(),
+	}
+	if filter.DeadlineAfter, err = parseDateParam(c, "deadline_after"); err == nil {
+		filter.DeadlineBefore, err = parseDateParam(c, "deadline_before")
+	}
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	projectList, total, err := h.Projects.ListProjects(c.Request.Context(), filter)
 	if err != nil {
-		// Ошибка sql.ErrNoRows здесь не возникает для Select, он вернет пустой слайс
 		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve projects"})
+		apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve projects")
 		return
 	}
 
-	// Всегда возвращаем список (пустой или с данными)
-	c.JSON(http.StatusOK, projectList)
+	c.JSON(http.StatusOK, ProjectListResponse{
+		Items:    projectList,
+		ListMeta: buildListMeta(c, params, total),
+	})
 }
 
 // CreateProject godoc
 // @Summary Create a new project
-// @Description Create a new project by providing the project details
+// @Description Create a new project by providing the project details. The authenticated user becomes its owner. The deadline is accepted as YYYY-MM-DD or DD.MM.YYYY, is returned as YYYY-MM-DD and must not be in the past.
 // @Tags Projects
 // @Accept  json
 // @Produce  json
-// @Param project body database.Project true "Project data (ID can be omitted or 0)" // <-- Используем db.Project
-// @Success 201 {object} database.Project "Project created successfully" // <-- Используем db.Project
-// @Failure 400 {object} map[string]string "Invalid input data format"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Param project body database.Project true "Project data (ID can be omitted or 0)"
+// @Success 201 {object} database.Project "Project created successfully"
+// @Header 201 {string} ETag "Project version"
+// @Failure 400 {object} apierror.Problem "Malformed JSON"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 422 {object} ValidationErrorResponse "Invalid project fields, including a malformed or past deadline"
+// @Failure 500 {object} apierror.Problem "Internal server error"
 // @Router /projects [post]
-func CreateProject(c *gin.Context) {
-	var newProject db.Project // <-- Используем db.Project
-	if err := c.ShouldBindJSON(&newProject); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data format", "details": err.Error()})
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	var newProject db.Project
+	if !bindJSON(c, &newProject, futureDeadline(&newProject.Deadline)) {
 		return
 	}
-
-	// Выполняем INSERT
-	query := `
-		INSERT INTO projects (name, description, deadline, experience)
-		VALUES (?, ?, ?, ?);
-	`
-	result, err := db.DB.Exec(query,
-		newProject.Name, newProject.Description, newProject.Deadline, newProject.Experience,
-	)
-	if err != nil {
-		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create project"})
-		return
+	// Владельца определяет токен, а не тело запроса
+	newProject.OwnerID = nil
+	if principal, ok := auth.CurrentUser(c); ok {
+		newProject.OwnerID = &principal.UserID
 	}
 
-	lastID, err := result.LastInsertId()
-	if err != nil {
+	if err := h.Projects.CreateProject(auditContext(c), &newProject); err != nil {
 		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get last insert ID"})
+		apierror.Respond(c, apierror.CodeInternal, "Failed to create project")
 		return
 	}
-	newProject.ID = uint(lastID) // Присваиваем ID
 
+	setETag(c, newProject.Version)
 	c.JSON(http.StatusCreated, newProject)
 }
 
+// futureDeadline проверяет, что дедлайн не в прошлом. Отсутствующий дедлайн
+// пропускает: о нем сообщает правило required.
+func futureDeadline(deadline *db.Date) fieldCheck {
+	return func() *FieldError {
+		if deadline.IsZero() || !deadline.Before(db.Today().Time) {
+			return nil
+		}
+		return &FieldError{Field: "deadline", Rule: "future", Reason: "must not be in the past"}
+	}
+}
+
 // EditProject godoc
 // @Summary Edit an existing project
-// @Description Edit a project by ID
+// @Description Edit a project by ID. Requires the owner or manager role in the project. The deadline is accepted as YYYY-MM-DD or DD.MM.YYYY; a past deadline is allowed here so that overdue projects stay editable.
 // @Tags Projects
 // @Accept  json
 // @Produce  json
+// @Security BearerAuth
 // @Param id path int true "Project ID"
-// @Param project body database.Project true "Updated project data (ID in body is ignored)" // <-- Используем db.Project
-// @Success 200 {object} database.Project "Project updated successfully" // <-- Используем db.Project
-// @Failure 400 {object} map[string]string "Invalid project ID format or invalid input data"
-// @Failure 404 {object} map[string]string "Project not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Param If-Match header string false "ETag of the project being edited; required if the server enforces it"
+// @Param project body database.Project true "Updated project data (ID and version in body are ignored)"
+// @Success 200 {object} database.Project "Project updated successfully"
+// @Header 200 {string} ETag "New project version"
+// @Failure 400 {object} apierror.Problem "Invalid project ID format, malformed JSON or If-Match"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the project"
+// @Failure 404 {object} apierror.Problem "Project not found"
+// @Failure 412 {object} apierror.Problem "Project changed since the ETag in If-Match"
+// @Failure 422 {object} ValidationErrorResponse "Invalid values in the fields the request changes"
+// @Failure 428 {object} apierror.Problem "If-Match is required but missing"
+// @Failure 500 {object} apierror.Problem "Internal server error"
 // @Router /projects/{id} [put]
-func EditProject(c *gin.Context) {
+func (h *ProjectHandler) EditProject(c *gin.Context) {
 	projectIDStr := c.Param("id")
 	projectID, err := strconv.ParseUint(projectIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		apierror.Respond(c, apierror.CodeInvalidParameter, "project ID must be a positive integer")
 		return
 	}
 
-	var updatedProjectData db.Project // <-- Используем db.Project
-	if err := c.ShouldBindJSON(&updatedProjectData); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data format", "details": err.Error()})
+	if !authorizeProject(c, h.Members, uint(projectID), editorRoles...) {
 		return
 	}
+	current, ok := h.loadProject(c, uint(projectID))
+	if !ok {
+		return
+	}
+
+	var updatedProjectData db.Project
+	if !bindUpdateJSON(c, current, &updatedProjectData) {
+		return
+	}
+	updatedProjectData.ID = current.ID // ID берем из URL
 
-	// Выполняем UPDATE
-	query := `
-		UPDATE projects SET
-			name = ?,
-			description = ?,
-			deadline = ?,
-			experience = ?
-		WHERE id = ?;
-	`
-	result, err := db.DB.Exec(query,
-		updatedProjectData.Name,
-		updatedProjectData.Description,
-		updatedProjectData.Deadline,
-		updatedProjectData.Experience,
-		uint(projectID), // ID проекта для условия WHERE
-	)
+	h.saveProject(c, &updatedProjectData)
+}
 
+// PatchProject godoc
+// @Summary Partially update a project
+// @Description Update only the fields present in the body, following JSON Merge Patch (RFC 7396): omitted fields keep their values and null clears a field. The patched project must pass the same validation as in PUT. Requires the owner or manager role in the project.
+// @Tags Projects
+// @Accept  json
+// @Accept  application/merge-patch+json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param If-Match header string false "ETag of the project being edited; required if the server enforces it"
+// @Param project body database.Project true "Fields to change"
+// @Success 200 {object} database.Project "Project updated successfully"
+// @Header 200 {string} ETag "New project version"
+// @Failure 400 {object} apierror.Problem "Invalid project ID format, malformed patch or If-Match"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the project"
+// @Failure 404 {object} apierror.Problem "Project not found"
+// @Failure 412 {object} apierror.Problem "Project changed since the ETag in If-Match"
+// @Failure 415 {object} apierror.Problem "Body is neither application/json nor application/merge-patch+json"
+// @Failure 422 {object} ValidationErrorResponse "Invalid values in the fields the patch changes"
+// @Failure 428 {object} apierror.Problem "If-Match is required but missing"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /projects/{id} [patch]
+func (h *ProjectHandler) PatchProject(c *gin.Context) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update project"})
+		apierror.Respond(c, apierror.CodeInvalidParameter, "project ID must be a positive integer")
+		return
+	}
+	if !authorizeProject(c, h.Members, uint(projectID), editorRoles...) {
 		return
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	current, ok := h.loadProject(c, uint(projectID))
+	if !ok {
+		return
+	}
+
+	var project db.Project
+	if _, ok := bindMergePatch(c, current, &project); !ok {
+		return
+	}
+	project.ID = current.ID
+	h.saveProject(c, &project)
+}
+
+// loadProject загружает проект для изменения; false - ответ уже отправлен
+func (h *ProjectHandler) loadProject(c *gin.Context, projectID uint) (db.Project, bool) {
+	project, err := h.Projects.GetProject(c.Request.Context(), projectID)
 	if err != nil {
-		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check rows affected after update"})
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeProjectNotFound, "")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve project")
+		}
+		return db.Project{}, false
+	}
+	return project, true
+}
+
+// saveProject сохраняет изменения проекта и отвечает проектом, заново прочитанным
+// из хранилища: владелец и дата архивации в теле запроса могли отсутствовать.
+// Ожидаемую версию берет из If-Match, а не из тела.
+func (h *ProjectHandler) saveProject(c *gin.Context, project *db.Project) {
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+	project.Version = version
+
+	ctx := auditContext(c)
+	if err := h.Projects.UpdateProject(ctx, project); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeProjectNotFound, "")
+		} else if errors.Is(err, repository.ErrVersionMismatch) {
+			respondPreconditionFailed(c)
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to update project")
+		}
 		return
 	}
 
-	if rowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+	saved, err := h.Projects.GetProject(ctx, project.ID)
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve project")
 		return
 	}
+	setETag(c, saved.Version)
+	c.JSON(http.StatusOK, saved)
+}
 
-	// Возвращаем обновленные данные (присваиваем ID для полноты)
-	updatedProjectData.ID = uint(projectID)
-	c.JSON(http.StatusOK, updatedProjectData)
+// DeleteProjectConflict - тело ответа 409 при политике restrict
+type DeleteProjectConflict struct {
+	apierror.Problem
+	Vacancies []repository.BlockingVacancy `json:"vacancies"`
 }
 
 // DeleteProject godoc
 // @Summary Delete an existing project
-// @Description Delete a project by ID (Note: This might fail if vacancies reference this project due to FOREIGN KEY constraint)
+// @Description Delete a project by ID. What happens to its vacancies depends on the server's delete policy: "cascade" moves them to the trash together with the project, "restrict" refuses with 409 while the project has vacancies, "archive" keeps the data and marks the project as archived. Deleted projects can be restored with POST /projects/{id}/restore until the trash is purged. Only project owners can delete it.
 // @Tags Projects
 // @Accept  json
 // @Produce  json
+// @Security BearerAuth
 // @Param id path int true "Project ID"
-// @Success 204 "Project deleted successfully" // <-- Нет тела
-// @Failure 400 {object} map[string]string "Invalid project ID format"
-// @Failure 404 {object} map[string]string "Project not found"
-// @Failure 500 {object} map[string]string "Internal server error (e.g., due to foreign key constraint)"
+// @Param If-Match header string false "ETag of the project being deleted; required if the server enforces it"
+// @Success 204 "Project deleted (or archived) successfully"
+// @Failure 400 {object} apierror.Problem "Invalid project ID format or If-Match"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner of the project"
+// @Failure 404 {object} apierror.Problem "Project not found"
+// @Failure 409 {object} DeleteProjectConflict "Project still has vacancies (restrict policy)"
+// @Failure 412 {object} apierror.Problem "Project changed since the ETag in If-Match"
+// @Failure 428 {object} apierror.Problem "If-Match is required but missing"
+// @Failure 500 {object} apierror.Problem "Internal server error"
 // @Router /projects/{id} [delete]
-func DeleteProject(c *gin.Context) {
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 	projectIDStr := c.Param("id")
 	projectID, err := strconv.ParseUint(projectIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		apierror.Respond(c, apierror.CodeInvalidParameter, "project ID must be a positive integer")
 		return
 	}
 
-	// Выполняем DELETE
-	// ВНИМАНИЕ: Если существуют вакансии, ссылающиеся на этот project_id,
-	// стандартный FOREIGN KEY constraint не даст удалить проект.
-	// Нужно либо сначала удалить вакансии, либо настроить каскадное удаление (ON DELETE CASCADE) в схеме БД.
-	query := "DELETE FROM projects WHERE id = ?"
-	result, err := db.DB.Exec(query, uint(projectID))
-	if err != nil {
-		// Здесь может быть ошибка SQLite FOREIGN KEY constraint failed
-		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete project", "details": err.Error()})
+	if !authorizeProject(c, h.Members, uint(projectID), ownerRoles...) {
 		return
 	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check rows affected after delete"})
+	version, ok := ifMatchVersion(c)
+	if !ok {
 		return
 	}
 
-	if rowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
-		return
+	err = h.Projects.DeleteProject(auditContext(c), uint(projectID), version, h.DeletePolicy)
+	var conflict *repository.ProjectHasVacanciesError
+	switch {
+	case err == nil:
+		// Успех - возвращаем 204 No Content
+		c.Status(http.StatusNoContent)
+	case errors.Is(err, repository.ErrNotFound):
+		apierror.Respond(c, apierror.CodeProjectNotFound, "")
+	case errors.Is(err, repository.ErrVersionMismatch):
+		respondPreconditionFailed(c)
+	case errors.As(err, &conflict):
+		apierror.Write(c, &DeleteProjectConflict{
+			Problem:   apierror.New(apierror.CodeProjectHasVacancies, "delete or move its vacancies first"),
+			Vacancies: conflict.Vacancies,
+		})
+	default:
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to delete project")
 	}
-
-	// Успех - возвращаем 204 No Content
-	c.Status(http.StatusNoContent)
 }
-
-
-
-// package handlers
-
-// import (
-// 	"log"
-// 	"net/http"
-// 	"strconv"
-// 	"sync"
-
-// 	"github.com/gin-gonic/gin"
-// 	"github.com/troodinc/trood-front-hackathon/models"
-// )
-
-// var (
-// 	projects      = make(map[int]models.Project)
-// 	nextProjectID = 1
-// 	projectMutex  sync.Mutex
-// )
-
-// func InitProjects() {
-// 	projectMutex.Lock()
-// 	defer projectMutex.Unlock()
-
-// 	projects[1] = models.Project{
-// 		ID:          1,
-// 		Name:        "Project Alpha",
-// 		Description: "A cutting-edge AI project",
-// 		Deadline:    "31.12.2025",
-// 		Experience:  "5+ years",
-// 	}
-// 	projects[2] = models.Project{
-// 		ID:          2,
-// 		Name:        "Project Beta",
-// 		Description: "Next-gen cloud platform",
-// 		Deadline:    "30.06.2025",
-// 		Experience:  "3+ years",
-// 	}
-// 	projects[3] = models.Project{
-// 		ID:          3,
-// 		Name:        "Project Gamma",
-// 		Description: "Blockchain-based fintech solution",
-// 		Deadline:    "15.09.2025",
-// 		Experience:  "4+ years",
-// 	}
-
-// 	nextProjectID = 4
-
-// 	log.Println("Initialized projects with sample data")
-// }
-
-// // GetProjectByID godoc
-// // @Summary Get a project by ID
-// // @Description Retrieve a project from the database by its ID
-// // @Tags Projects
-// // @Accept  json
-// // @Produce  json
-// // @Param id path int true "Project ID"
-// // @Success 200 {object} models.Project
-// // @Failure 404 {object} map[string]interface{} "Project not found"
-// // @Router /projects/{id} [get]
-// func GetProjectByID(c *gin.Context) {
-// 	idStr := c.Param("id")
-// 	id, err := strconv.Atoi(idStr)
-// 	if err != nil {
-// 		c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Invalid project ID"})
-// 		return
-// 	}
-
-// 	projectMutex.Lock()
-// 	defer projectMutex.Unlock()
-
-// 	if project, exists := projects[id]; exists {
-// 		c.JSON(http.StatusOK, project)
-// 	} else {
-// 		c.JSON(http.StatusNotFound, map[string]interface{}{"error": "Project not found"})
-// 	}
-// }
-
-// // GetProjects godoc
-// // @Summary Get all projects
-// // @Description Retrieve all projects from the database
-// // @Tags Projects
-// // @Accept  json
-// // @Produce  json
-// // @Success 200 {array} models.Project
-// // @Router /projects [get]
-// func GetProjects(c *gin.Context) {
-// 	projectMutex.Lock()
-// 	defer projectMutex.Unlock()
-
-// 	var projectList []models.Project
-// 	for _, project := range projects {
-// 		projectList = append(projectList, project)
-// 	}
-
-// 	if len(projectList) == 0 {
-// 		c.JSON(http.StatusOK, []models.Project{})
-// 		return
-// 	}
-
-// 	c.JSON(http.StatusOK, projectList)
-// }
-
-// // CreateProject godoc
-// // @Summary Create a new project
-// // @Description Create a new project by providing the project details
-// // @Tags Projects
-// // @Accept  json
-// // @Produce  json
-// // @Param project body models.Project true "Project data"
-// // @Success 201 {object} models.Project
-// // @Router /projects [post]
-// func CreateProject(c *gin.Context) {
-// 	var project models.Project
-// 	if err := c.ShouldBindJSON(&project); err != nil {
-// 		c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Invalid input"})
-// 		return
-// 	}
-
-// 	projectMutex.Lock()
-// 	defer projectMutex.Unlock()
-
-// 	project.ID = nextProjectID
-// 	nextProjectID++
-// 	projects[project.ID] = project
-
-// 	c.JSON(http.StatusCreated, project)
-// }
-
-// // EditProject godoc
-// // @Summary Edit an existing project
-// // @Description Edit a project by ID
-// // @Tags Projects
-// // @Accept  json
-// // @Produce  json
-// // @Param id path int true "Project ID"
-// // @Param project body models.Project true "Updated project data"
-// // @Success 200 {object} models.Project
-// // @Failure 404 {object} map[string]interface{} "Project not found"
-// // @Router /projects/{id} [put]
-// func EditProject(c *gin.Context) {
-// 	idStr := c.Param("id")
-// 	id, err := strconv.Atoi(idStr)
-// 	if err != nil {
-// 		c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Invalid project ID"})
-// 		return
-// 	}
-
-// 	var project models.Project
-// 	if err := c.ShouldBindJSON(&project); err != nil {
-// 		c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Invalid input"})
-// 		return
-// 	}
-
-// 	projectMutex.Lock()
-// 	defer projectMutex.Unlock()
-
-// 	if existingProject, exists := projects[id]; exists {
-// 		existingProject.Name = project.Name
-// 		existingProject.Description = project.Description
-// 		existingProject.Deadline = project.Deadline
-// 		existingProject.Experience = project.Experience
-// 		projects[id] = existingProject
-
-// 		c.JSON(http.StatusOK, existingProject)
-// 	} else {
-// 		c.JSON(http.StatusNotFound, map[string]interface{}{"error": "Project not found"})
-// 	}
-// }
-
-// // DeleteProject godoc
-// // @Summary Delete an existing project
-// // @Description Delete a project by ID
-// // @Tags Projects
-// // @Accept  json
-// // @Produce  json
-// // @Param id path int true "Project ID"
-// // @Success 204 {object} map[string]interface{} "No Content"
-// // @Failure 404 {object} map[string]interface{} "Project not found"
-// // @Router /projects/{id} [delete]
-// func DeleteProject(c *gin.Context) {
-// 	idStr := c.Param("id")
-// 	id, err := strconv.Atoi(idStr)
-// 	if err != nil {
-// 		c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Invalid project ID"})
-// 		return
-// 	}
-
-// 	projectMutex.Lock()
-// 	defer projectMutex.Unlock()
-
-// 	if _, exists := projects[id]; exists {
-// 		// Delete the project
-// 		delete(projects, id)
-
-// 		c.JSON(http.StatusNoContent, map[string]interface{}{"message": "Project deleted"})
-// 	} else {
-// 		c.JSON(http.StatusNotFound, map[string]interface{}{"error": "Project not found"})
-// 	}
-// }
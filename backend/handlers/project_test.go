@@ -0,0 +1,452 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/troodinc/trood-front-hackathon/apierror"
+	"github.com/troodinc/trood-front-hackathon/auth"
+	db "github.com/troodinc/trood-front-hackathon/database"
+	"github.com/troodinc/trood-front-hackathon/repository"
+)
+
+// testServer - маршруты проектов и регистрации поверх MemoryStore, собранные так же, как в main.go
+type testServer struct {
+	store  *repository.MemoryStore
+	router *gin.Engine
+	// token - access-токен владельца проектов, созданных через createProject
+	token   string
+	ownerID uint
+}
+
+func newTestServer(t *testing.T, requireIfMatch bool) *testServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	keys, err := auth.NewHMACKeySet(strings.Repeat("s", auth.MinHMACSecretLength))
+	if err != nil {
+		t.Fatalf("NewHMACKeySet: %v", err)
+	}
+	tokens := &auth.TokenService{Keys: keys, Issuer: "test", Audience: "test", AccessTTL: time.Hour}
+
+	store := repository.NewMemoryStore()
+	owner := db.User{Email: "owner@example.com", Name: "Owner", PasswordHash: "-", CreatedAt: time.Now().UTC()}
+	if err := store.CreateUser(context.Background(), &owner); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	token, _, err := tokens.IssueAccessToken(owner)
+	if err != nil {
+		t.Fatalf("IssueAccessToken: %v", err)
+	}
+
+	authHandler := NewAuthHandler(store, tokens, time.Hour)
+	projectHandler := NewProjectHandler(store, store, store, repository.DeletePolicyCascade)
+	requireAuth := auth.RequireAuth(tokens)
+	ifMatch := IfMatchPolicy(requireIfMatch)
+
+	r := gin.New()
+	r.Use(apierror.RequestID(), apierror.Recovery())
+	r.POST("/auth/register", authHandler.Register)
+	projectRoutes := r.Group("/projects")
+	{
+		projectRoutes.GET("", projectHandler.GetProjects)
+		projectRoutes.POST("", requireAuth, projectHandler.CreateProject)
+		projectRoutes.PUT("/:id", requireAuth, ifMatch, projectHandler.EditProject)
+		projectRoutes.PATCH("/:id", requireAuth, ifMatch, projectHandler.PatchProject)
+	}
+	return &testServer{store: store, router: r, token: token, ownerID: owner.ID}
+}
+
+// createProject добавляет проект владельца сервера с дедлайном через daysLeft дней
+func (s *testServer) createProject(t *testing.T, name, experience string, daysLeft int) db.Project {
+	t.Helper()
+	project := db.Project{
+		Name:        name,
+		Description: "About " + name,
+		Deadline:    db.DateOf(time.Now().UTC().AddDate(0, 0, daysLeft)),
+		Experience:  experience,
+		OwnerID:     &s.ownerID,
+	}
+	if err := s.store.CreateProject(context.Background(), &project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	return project
+}
+
+// do выполняет запрос от имени владельца; headers - пары имя, значение
+func (s *testServer) do(method, target, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// decodeBody разбирает JSON-ответ в dest
+func decodeBody(t *testing.T, w *httptest.ResponseRecorder, dest any) {
+	t.Helper()
+	if err := json.Unmarshal(w.Body.Bytes(), dest); err != nil {
+		t.Fatalf("decode %s: %v", w.Body, err)
+	}
+}
+
+func TestGetProjects(t *testing.T) {
+	s := newTestServer(t, false)
+	for i, name := range []string{"Alpha", "Bravo", "Charlie", "Delta", "Echo"} {
+		experience := "1 year"
+		if i%2 == 1 {
+			experience = "3+ years"
+		}
+		s.createProject(t, name, experience, 10*(i+1))
+	}
+	deadline := func(days int) string {
+		return db.DateOf(time.Now().UTC().AddDate(0, 0, days)).String()
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantNames  []string
+		wantMeta   ListMeta
+	}{
+		{
+			name: "first page", query: "?limit=2", wantStatus: http.StatusOK,
+			wantNames: []string{"Alpha", "Bravo"},
+			wantMeta:  ListMeta{Total: 5, Limit: 2, Page: 1, Pages: 3, Next: "/projects?limit=2&page=2"},
+		},
+		{
+			name: "last page", query: "?limit=2&page=3", wantStatus: http.StatusOK,
+			wantNames: []string{"Echo"},
+			wantMeta:  ListMeta{Total: 5, Limit: 2, Page: 3, Pages: 3, Prev: "/projects?limit=2&page=2"},
+		},
+		{
+			name: "experience", query: "?experience=3%2B+years", wantStatus: http.StatusOK,
+			wantNames: []string{"Bravo", "Delta"},
+			wantMeta:  ListMeta{Total: 2, Limit: defaultPageLimit, Page: 1, Pages: 1},
+		},
+		{
+			name: "deadline range", query: "?deadline_after=" + deadline(20) + "&deadline_before=" + deadline(30), wantStatus: http.StatusOK,
+			wantNames: []string{"Bravo", "Charlie"},
+			wantMeta:  ListMeta{Total: 2, Limit: defaultPageLimit, Page: 1, Pages: 1},
+		},
+		{
+			name: "sort by name desc", query: "?sort=name&order=desc&limit=1", wantStatus: http.StatusOK,
+			wantNames: []string{"Echo"},
+			wantMeta:  ListMeta{Total: 5, Limit: 1, Page: 1, Pages: 5, Next: "/projects?limit=1&order=desc&page=2&sort=name"},
+		},
+		{name: "limit too large", query: "?limit=1000", wantStatus: http.StatusBadRequest},
+		{name: "page is not a number", query: "?page=first", wantStatus: http.StatusBadRequest},
+		{name: "page offset overflows", query: "?limit=100&page=" + strconv.Itoa(math.MaxInt/50), wantStatus: http.StatusBadRequest},
+		{name: "unknown sort field", query: "?sort=owner", wantStatus: http.StatusBadRequest},
+		{name: "malformed deadline", query: "?deadline_after=someday", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := s.do(http.MethodGet, "/projects"+tt.query, "")
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				var problem apierror.Problem
+				decodeBody(t, w, &problem)
+				if problem.Code != apierror.CodeInvalidParameter {
+					t.Errorf("code = %q, want %q", problem.Code, apierror.CodeInvalidParameter)
+				}
+				return
+			}
+
+			var response ProjectListResponse
+			decodeBody(t, w, &response)
+			names := make([]string, len(response.Items))
+			for i, p := range response.Items {
+				names[i] = p.Name
+			}
+			if !slices.Equal(names, tt.wantNames) {
+				t.Errorf("projects = %v, want %v", names, tt.wantNames)
+			}
+			if response.ListMeta != tt.wantMeta {
+				t.Errorf("meta = %+v, want %+v", response.ListMeta, tt.wantMeta)
+			}
+		})
+	}
+}
+
+func TestCreateProjectValidation(t *testing.T) {
+	s := newTestServer(t, false)
+	yesterday := db.DateOf(time.Now().UTC().AddDate(0, 0, -1)).String()
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantFields []string
+	}{
+		{
+			name:       "valid",
+			body:       `{"name": "Landing", "deadline": "2099-01-01", "experience": "1 year"}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "empty name and past deadline",
+			body:       `{"name": " ", "deadline": "` + yesterday + `", "experience": "1 year"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantFields: []string{"deadline", "name"},
+		},
+		{
+			name:       "past deadline only",
+			body:       `{"name": "Landing", "deadline": "` + yesterday + `", "experience": "1 year"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantFields: []string{"deadline"},
+		},
+		{
+			name:       "malformed JSON",
+			body:       `{"name": `,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := s.do(http.MethodPost, "/projects", tt.body)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantFields == nil {
+				return
+			}
+			if fields := invalidFields(t, w); !slices.Equal(fields, tt.wantFields) {
+				t.Errorf("fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+// invalidFields - отсортированные имена полей из ответа 422
+func invalidFields(t *testing.T, w *httptest.ResponseRecorder) []string {
+	t.Helper()
+	var response ValidationErrorResponse
+	decodeBody(t, w, &response)
+	fields := make([]string, len(response.Fields))
+	for i, f := range response.Fields {
+		fields[i] = f.Field
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+// Проекты, сохраненные до появления правил, можно править, не трогая некорректные поля
+func TestEditLegacyProject(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+		wantFields []string
+	}{
+		{
+			name: "patch keeps the legacy value", method: http.MethodPatch,
+			body: `{"name": "Renamed"}`, wantStatus: http.StatusOK,
+		},
+		{
+			name: "put keeps the legacy value", method: http.MethodPut,
+			body: `{"name": "Renamed", "deadline": "2099-01-01", "experience": "Senior"}`, wantStatus: http.StatusOK,
+		},
+		{
+			name: "patch changes it to an invalid value", method: http.MethodPatch,
+			body: `{"experience": "Junior"}`, wantStatus: http.StatusUnprocessableEntity,
+			wantFields: []string{"experience"},
+		},
+		{
+			name: "put changes it to an invalid value", method: http.MethodPut,
+			body: `{"name": " ", "deadline": "2099-01-01", "experience": "Junior"}`, wantStatus: http.StatusUnprocessableEntity,
+			wantFields: []string{"experience", "name"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, false)
+			s.createProject(t, "Landing", "Senior", 30)
+
+			w := s.do(tt.method, "/projects/1", tt.body)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantFields == nil {
+				return
+			}
+			if fields := invalidFields(t, w); !slices.Equal(fields, tt.wantFields) {
+				t.Errorf("fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestRegisterValidation(t *testing.T) {
+	s := newTestServer(t, false)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantFields []string
+	}{
+		{
+			name:       "valid",
+			body:       `{"email": "new@example.com", "name": "New", "password": "secret123"}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "every field is reported",
+			body:       `{"email": "not an address", "name": " ", "password": "short"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantFields: []string{"email", "name", "password"},
+		},
+		{
+			name:       "missing fields",
+			body:       `{}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantFields: []string{"email", "name", "password"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := s.do(http.MethodPost, "/auth/register", tt.body)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantFields == nil {
+				return
+			}
+			if fields := invalidFields(t, w); !slices.Equal(fields, tt.wantFields) {
+				t.Errorf("fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestPatchProject(t *testing.T) {
+	tests := []struct {
+		name            string
+		contentType     string
+		body            string
+		wantStatus      int
+		wantName        string
+		wantDescription string
+	}{
+		{
+			name: "omitted fields are kept", contentType: mergePatchContentType,
+			body: `{"name": "Renamed"}`, wantStatus: http.StatusOK,
+			wantName: "Renamed", wantDescription: "About Landing",
+		},
+		{
+			name: "null clears a field", contentType: mergePatchContentType,
+			body: `{"description": null}`, wantStatus: http.StatusOK,
+			wantName: "Landing", wantDescription: "",
+		},
+		{
+			name: "plain JSON is accepted", contentType: "application/json",
+			body: `{"description": "New"}`, wantStatus: http.StatusOK,
+			wantName: "Landing", wantDescription: "New",
+		},
+		{
+			name: "null on a required field", contentType: mergePatchContentType,
+			body: `{"name": null}`, wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "not an object", contentType: mergePatchContentType,
+			body: `["name"]`, wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unsupported media type", contentType: "text/plain",
+			body: `{"name": "Renamed"}`, wantStatus: http.StatusUnsupportedMediaType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, false)
+			project := s.createProject(t, "Landing", "1 year", 30)
+
+			w := s.do(http.MethodPatch, "/projects/1", tt.body, "Content-Type", tt.contentType)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			saved, err := s.store.GetProject(context.Background(), project.ID)
+			if err != nil {
+				t.Fatalf("GetProject: %v", err)
+			}
+			if tt.wantStatus != http.StatusOK {
+				if saved.Version != project.Version {
+					t.Errorf("rejected patch changed the project to version %d", saved.Version)
+				}
+				return
+			}
+			if saved.Name != tt.wantName || saved.Description != tt.wantDescription {
+				t.Errorf("project = %q / %q, want %q / %q", saved.Name, saved.Description, tt.wantName, tt.wantDescription)
+			}
+			if saved.Deadline != project.Deadline || saved.Experience != project.Experience {
+				t.Errorf("patch changed fields it did not mention: %+v", saved)
+			}
+		})
+	}
+}
+
+func TestProjectIfMatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		requireIfMatch bool
+		// ifMatch - значение If-Match; пусто - заголовок не передается
+		ifMatch    string
+		wantStatus int
+		wantCode   apierror.Code
+		wantETag   string
+	}{
+		{name: "current version", ifMatch: `"1"`, wantStatus: http.StatusOK, wantETag: `"2"`},
+		{name: "any version", ifMatch: "*", wantStatus: http.StatusOK, wantETag: `"2"`},
+		{name: "without If-Match", wantStatus: http.StatusOK, wantETag: `"2"`},
+		{name: "stale version", ifMatch: `"7"`, wantStatus: http.StatusPreconditionFailed, wantCode: apierror.CodePreconditionFailed},
+		{name: "weak ETag", ifMatch: `W/"1"`, wantStatus: http.StatusBadRequest, wantCode: apierror.CodeInvalidParameter},
+		{name: "unquoted version", ifMatch: "1", wantStatus: http.StatusBadRequest, wantCode: apierror.CodeInvalidParameter},
+		{name: "required and missing", requireIfMatch: true, wantStatus: http.StatusPreconditionRequired, wantCode: apierror.CodePreconditionRequired},
+		{name: "required and present", requireIfMatch: true, ifMatch: `"1"`, wantStatus: http.StatusOK, wantETag: `"2"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, tt.requireIfMatch)
+			s.createProject(t, "Landing", "1 year", 30)
+
+			var headers []string
+			if tt.ifMatch != "" {
+				headers = []string{"If-Match", tt.ifMatch}
+			}
+			w := s.do(http.MethodPatch, "/projects/1", `{"name": "Renamed"}`, headers...)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("ETag = %q, want %q", got, tt.wantETag)
+			}
+			if tt.wantCode != "" {
+				var problem apierror.Problem
+				decodeBody(t, w, &problem)
+				if problem.Code != tt.wantCode {
+					t.Errorf("code = %q, want %q", problem.Code, tt.wantCode)
+				}
+			}
+		})
+	}
+}
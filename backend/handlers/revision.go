@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/troodinc/trood-front-hackathon/apierror"
+	db "github.com/troodinc/trood-front-hackathon/database"
+	"github.com/troodinc/trood-front-hackathon/repository"
+)
+
+// RevisionHandler - история содержимого проектов и вакансий и откат к прежней версии
+type RevisionHandler struct {
+	Revisions repository.RevisionRepository
+	Projects  repository.ProjectRepository
+	Vacancies repository.VacancyRepository
+	Members   repository.MemberRepository
+}
+
+// NewRevisionHandler создает обработчики ревизий поверх хранилища
+func NewRevisionHandler(revisions repository.RevisionRepository, projects repository.ProjectRepository, vacancies repository.VacancyRepository, members repository.MemberRepository) *RevisionHandler {
+	return &RevisionHandler{Revisions: revisions, Projects: projects, Vacancies: vacancies, Members: members}
+}
+
+// RevisionListResponse - постраничный ответ для списков ревизий
+type RevisionListResponse struct {
+	Items []db.Revision `json:"items"`
+	ListMeta
+}
+
+// ListProjectRevisions godoc
+// @Summary List project revisions
+// @Description Retrieve a page of saved versions of the project's name, description, deadline and experience, newest first. Revision 1 is the content before the first edit; every edit adds the next one. changes lists the fields that differ from the previous revision. Requires the owner or manager role in the project.
+// @Tags Projects
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param page query int false "Page number, starting from 1" default(1)
+// @Success 200 {object} RevisionListResponse "Page of revisions"
+// @Failure 400 {object} apierror.Problem "Invalid project ID or query parameters"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the project"
+// @Failure 404 {object} apierror.Problem "Project not found"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /projects/{id}/revisions [get]
+func (h *RevisionHandler) ListProjectRevisions(c *gin.Context) {
+	project, ok := h.authorizeProject(c)
+	if !ok {
+		return
+	}
+	h.listRevisions(c, "project", project.ID)
+}
+
+// RestoreProjectRevision godoc
+// @Summary Restore a project revision
+// @Description Bring the project's name, description, deadline and experience back to the given revision. The restore is saved as a new revision; nothing in the history is removed. Requires the owner or manager role in the project.
+// @Tags Projects
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param rev path int true "Revision number"
+// @Param If-Match header string false "ETag of the project being changed"
+// @Success 200 {object} database.Project "Project with the restored content"
+// @Header 200 {string} ETag "New project version"
+// @Failure 400 {object} apierror.Problem "Invalid project ID, revision number or If-Match"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the project"
+// @Failure 404 {object} apierror.Problem "Project or revision not found"
+// @Failure 412 {object} apierror.Problem "Project changed since the ETag in If-Match"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /projects/{id}/revisions/{rev}/restore [post]
+func (h *RevisionHandler) RestoreProjectRevision(c *gin.Context) {
+	project, ok := h.authorizeProject(c)
+	if !ok {
+		return
+	}
+	var content db.ProjectContent
+	if !h.revisionContent(c, "project", project.ID, &content) {
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	project.SetContent(content)
+	project.Version = version
+	ctx := auditContext(c)
+	if err := h.Projects.UpdateProject(ctx, &project); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeProjectNotFound, "")
+		} else if errors.Is(err, repository.ErrVersionMismatch) {
+			respondPreconditionFailed(c)
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to update project")
+		}
+		return
+	}
+
+	saved, err := h.Projects.GetProject(ctx, project.ID)
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve project")
+		return
+	}
+	setETag(c, saved.Version)
+	c.JSON(http.StatusOK, saved)
+}
+
+// ListVacancyRevisions godoc
+// @Summary List vacancy revisions
+// @Description Retrieve a page of saved versions of the vacancy's name, description, field, country and experience, newest first. Revision 1 is the content before the first edit; every edit adds the next one. changes lists the fields that differ from the previous revision. Requires the owner or manager role in the vacancy's project.
+// @Tags vacancies
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Vacancy ID"
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param page query int false "Page number, starting from 1" default(1)
+// @Success 200 {object} RevisionListResponse "Page of revisions"
+// @Failure 400 {object} apierror.Problem "Invalid vacancy ID or query parameters"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the vacancy's project"
+// @Failure 404 {object} apierror.Problem "Vacancy not found"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /vacancies/{id}/revisions [get]
+func (h *RevisionHandler) ListVacancyRevisions(c *gin.Context) {
+	vacancy, ok := h.authorizeVacancy(c)
+	if !ok {
+		return
+	}
+	h.listRevisions(c, "vacancy", vacancy.ID)
+}
+
+// RestoreVacancyRevision godoc
+// @Summary Restore a vacancy revision
+// @Description Bring the vacancy's name, description, field, country and experience back to the given revision. Openings and status are not changed. The restore is saved as a new revision; nothing in the history is removed. Requires the owner or manager role in the vacancy's project.
+// @Tags vacancies
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Vacancy ID"
+// @Param rev path int true "Revision number"
+// @Param If-Match header string false "ETag of the vacancy being changed"
+// @Success 200 {object} database.Vacancy "Vacancy with the restored content"
+// @Header 200 {string} ETag "New vacancy version"
+// @Failure 400 {object} apierror.Problem "Invalid vacancy ID, revision number or If-Match"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the vacancy's project"
+// @Failure 404 {object} apierror.Problem "Vacancy or revision not found"
+// @Failure 412 {object} apierror.Problem "Vacancy changed since the ETag in If-Match"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /vacancies/{id}/revisions/{rev}/restore [post]
+func (h *RevisionHandler) RestoreVacancyRevision(c *gin.Context) {
+	vacancy, ok := h.authorizeVacancy(c)
+	if !ok {
+		return
+	}
+	var content db.VacancyContent
+	if !h.revisionContent(c, "vacancy", vacancy.ID, &content) {
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	vacancy.SetContent(content)
+	vacancy.Version = version
+	// Нулевое число мест UpdateVacancy не меняет - см. VacancyRepository.UpdateVacancy
+	vacancy.Openings = 0
+	ctx := auditContext(c)
+	if err := h.Vacancies.UpdateVacancy(ctx, &vacancy); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeVacancyNotFound, "")
+		} else if errors.Is(err, repository.ErrVersionMismatch) {
+			respondPreconditionFailed(c)
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to update vacancy")
+		}
+		return
+	}
+
+	saved, err := h.Vacancies.GetVacancy(ctx, vacancy.ID)
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve vacancy")
+		return
+	}
+	setETag(c, saved.Version)
+	c.JSON(http.StatusOK, saved)
+}
+
+// authorizeProject читает проект из пути и проверяет право его изменять.
+// Если что-то не так, сам отвечает ошибкой и возвращает false.
+func (h *RevisionHandler) authorizeProject(c *gin.Context) (db.Project, bool) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "project ID must be a positive integer")
+		return db.Project{}, false
+	}
+	if !authorizeProject(c, h.Members, uint(projectID), editorRoles...) {
+		return db.Project{}, false
+	}
+
+	project, err := h.Projects.GetProject(c.Request.Context(), uint(projectID))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeProjectNotFound, "")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve project")
+		}
+		return db.Project{}, false
+	}
+	return project, true
+}
+
+// authorizeVacancy читает вакансию из пути и проверяет право изменять ее проект.
+// Если что-то не так, сам отвечает ошибкой и возвращает false.
+func (h *RevisionHandler) authorizeVacancy(c *gin.Context) (db.Vacancy, bool) {
+	vacancyID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "vacancy ID must be a positive integer")
+		return db.Vacancy{}, false
+	}
+
+	vacancy, err := h.Vacancies.GetVacancy(c.Request.Context(), uint(vacancyID))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeVacancyNotFound, "")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to fetch vacancy")
+		}
+		return db.Vacancy{}, false
+	}
+	return vacancy, authorizeProject(c, h.Members, vacancy.ProjectID, editorRoles...)
+}
+
+// listRevisions отвечает страницей ревизий записи
+func (h *RevisionHandler) listRevisions(c *gin.Context, entityType string, entityID uint) {
+	params, err := parsePageParams(c)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	revisions, total, err := h.Revisions.ListRevisions(c.Request.Context(), entityType, entityID, params.Window())
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve revisions")
+		return
+	}
+
+	c.JSON(http.StatusOK, RevisionListResponse{
+		Items:    revisions,
+		ListMeta: buildListMeta(c, params, total),
+	})
+}
+
+// revisionContent читает содержимое ревизии из пути (:rev) в content.
+// Если ревизии нет или номер неверный, сам отвечает ошибкой и возвращает false.
+func (h *RevisionHandler) revisionContent(c *gin.Context, entityType string, entityID uint, content any) bool {
+	number, err := strconv.Atoi(c.Param("rev"))
+	if err != nil || number < 1 {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "revision must be a positive integer")
+		return false
+	}
+
+	revision, err := h.Revisions.GetRevision(c.Request.Context(), entityType, entityID, number)
+	if err == nil {
+		err = json.Unmarshal(revision.Content, content)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeRevisionNotFound, "")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve revision")
+		}
+		return false
+	}
+	return true
+}
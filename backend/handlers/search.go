@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/troodinc/trood-front-hackathon/apierror"
+	db "github.com/troodinc/trood-front-hackathon/database"
+	"github.com/troodinc/trood-front-hackathon/repository"
+)
+
+// SearchHandler - обработчик полнотекстового поиска
+type SearchHandler struct {
+	Search repository.SearchRepository
+}
+
+// NewSearchHandler создает обработчик поиска поверх хранилища
+func NewSearchHandler(search repository.SearchRepository) *SearchHandler {
+	return &SearchHandler{Search: search}
+}
+
+// SearchResponse - постраничный ответ для GET /search
+type SearchResponse struct {
+	Items []db.SearchResult `json:"items"`
+	ListMeta
+}
+
+// SearchAll godoc
+// @Summary Full-text search over projects and vacancies
+// @Description Search project and vacancy names and descriptions. Archived projects and their vacancies are excluded. Results are ordered by relevance; matched words are wrapped in <mark> tags.
+// @Tags search
+// @Accept  json
+// @Produce  json
+// @Param q query string true "Search query"
+// @Param type query string false "Restrict results to one entity type" Enums(project, vacancy)
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param page query int false "Page number, starting from 1" default(1)
+// @Success 200 {object} SearchResponse "Search results"
+// @Failure 400 {object} apierror.Problem "Missing query or invalid parameters"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /search [get]
+func (h *SearchHandler) SearchAll(c *gin.Context) {
+	text := strings.TrimSpace(c.Query("q"))
+	if text == "" {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "query parameter q is required")
+		return
+	}
+
+	params, err := parsePageParams(c)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	entityType := c.Query("type")
+	if entityType != "" && entityType != "project" && entityType != "vacancy" {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "type must be 'project' or 'vacancy'")
+		return
+	}
+
+	results, total, err := h.Search.Search(c.Request.Context(), repository.SearchQuery{
+		Text: text,
+		Type: entityType,
+		Page: params.Window(),
+	})
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to search")
+		return
+	}
+
+	c.JSON(http.StatusOK, SearchResponse{
+		Items:    results,
+		ListMeta: buildListMeta(c, params, total),
+	})
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/troodinc/trood-front-hackathon/apierror"
+	db "github.com/troodinc/trood-front-hackathon/database"
+	"github.com/troodinc/trood-front-hackathon/repository"
+)
+
+// TeamHandler - команда проекта (/projects/:id/team)
+type TeamHandler struct {
+	Team     repository.TeamRepository
+	Projects repository.ProjectRepository
+	Members  repository.MemberRepository
+}
+
+// NewTeamHandler создает обработчики команды поверх хранилища
+func NewTeamHandler(team repository.TeamRepository, projects repository.ProjectRepository, members repository.MemberRepository) *TeamHandler {
+	return &TeamHandler{Team: team, Projects: projects, Members: members}
+}
+
+// AddTeamMemberRequest - тело POST /projects/:id/team
+type AddTeamMemberRequest struct {
+	Name     string `json:"name" binding:"required" example:"Jamie Doe"`
+	Position string `json:"position" example:"Product designer"`
+	// VacancyID - вакансия проекта, на которую принят человек; занимает одно ее место
+	VacancyID *uint `json:"vacancy_id" example:"4"`
+}
+
+// TeamResponse - команда проекта и ее укомплектованность
+type TeamResponse struct {
+	Items []db.TeamMember `json:"items"`
+	db.ProjectStaffing
+}
+
+// GetTeam godoc
+// @Summary Get the project team
+// @Description People hired onto the project, in the order they joined, with the headcount and the number of open positions
+// @Tags team
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Success 200 {object} TeamResponse "Project team"
+// @Failure 400 {object} apierror.Problem "Invalid project ID format"
+// @Failure 404 {object} apierror.Problem "Project not found"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /projects/{id}/team [get]
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "project ID must be a positive integer")
+		return
+	}
+
+	ctx := auditContext(c)
+	if _, err := h.Projects.GetProject(ctx, uint(projectID)); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeProjectNotFound, "")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve team")
+		}
+		return
+	}
+
+	members, err := h.Team.ListTeamMembers(ctx, uint(projectID))
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve team")
+		return
+	}
+	staffing, err := h.Team.GetProjectStaffing(ctx, uint(projectID))
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve team")
+		return
+	}
+
+	c.JSON(http.StatusOK, TeamResponse{Items: members, ProjectStaffing: staffing})
+}
+
+// AddTeamMember godoc
+// @Summary Add a person to the project team
+// @Description Record that someone has been hired onto the project. With vacancy_id the person fills one opening of that vacancy; the vacancy closes when no openings are left. Requires the owner or manager role in the project.
+// @Tags team
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param member body AddTeamMemberRequest true "Team member"
+// @Success 201 {object} database.TeamMember "Added to the team"
+// @Failure 400 {object} apierror.Problem "Invalid project ID format or malformed JSON"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the project"
+// @Failure 404 {object} apierror.Problem "Project not found, or the vacancy does not belong to it"
+// @Failure 409 {object} apierror.Problem "The vacancy has no openings left or is not open"
+// @Failure 422 {object} ValidationErrorResponse "Invalid team member fields"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /projects/{id}/team [post]
+func (h *TeamHandler) AddTeamMember(c *gin.Context) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "project ID must be a positive integer")
+		return
+	}
+
+	var input AddTeamMemberRequest
+	if !bindJSON(c, &input) {
+		return
+	}
+	member := db.TeamMember{
+		ProjectID: uint(projectID),
+		VacancyID: input.VacancyID,
+		Name:      strings.TrimSpace(input.Name),
+		Position:  strings.TrimSpace(input.Position),
+	}
+	if member.Name == "" {
+		respondValidationError(c, FieldError{Field: "name", Rule: "notblank", Reason: "must not be blank"})
+		return
+	}
+
+	if !authorizeProject(c, h.Members, member.ProjectID, editorRoles...) {
+		return
+	}
+
+	if err := h.Team.AddTeamMember(auditContext(c), &member); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			apierror.Respond(c, apierror.CodeVacancyNotFound, "the vacancy does not belong to this project")
+		case errors.Is(err, repository.ErrNoOpenings):
+			apierror.Respond(c, apierror.CodeNoOpenings, "")
+		case errors.Is(err, repository.ErrVacancyNotOpen):
+			apierror.Respond(c, apierror.CodeVacancyNotOpen, "open the vacancy before hiring for it")
+		default:
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to add team member")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+// RemoveTeamMember godoc
+// @Summary Remove a person from the project team
+// @Description Undo a hire. If the person filled a vacancy, the opening is returned; a vacancy closed by its last hire reopens, one closed by hand stays closed. Requires the owner or manager role in the project.
+// @Tags team
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param member_id path int true "Team member ID"
+// @Success 204 "Removed from the team"
+// @Failure 400 {object} apierror.Problem "Invalid ID format"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the project"
+// @Failure 404 {object} apierror.Problem "Project or team member not found"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /projects/{id}/team/{member_id} [delete]
+func (h *TeamHandler) RemoveTeamMember(c *gin.Context) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "project ID must be a positive integer")
+		return
+	}
+	memberID, err := strconv.ParseUint(c.Param("member_id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "team member ID must be a positive integer")
+		return
+	}
+
+	if !authorizeProject(c, h.Members, uint(projectID), editorRoles...) {
+		return
+	}
+
+	if err := h.Team.RemoveTeamMember(auditContext(c), uint(projectID), uint(memberID)); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeTeamMemberNotFound, "")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to remove team member")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
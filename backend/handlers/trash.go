@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/troodinc/trood-front-hackathon/apierror"
+	"github.com/troodinc/trood-front-hackathon/auth"
+	db "github.com/troodinc/trood-front-hackathon/database"
+	"github.com/troodinc/trood-front-hackathon/repository"
+)
+
+// TrashHandler - корзина: список удаленных проектов и вакансий и их восстановление
+type TrashHandler struct {
+	Trash   repository.TrashRepository
+	Members repository.MemberRepository
+	// Retention - через сколько после удаления записи удаляются окончательно; 0 - никогда
+	Retention time.Duration
+}
+
+// NewTrashHandler создает обработчики корзины поверх хранилища
+func NewTrashHandler(trash repository.TrashRepository, members repository.MemberRepository, retention time.Duration) *TrashHandler {
+	return &TrashHandler{Trash: trash, Members: members, Retention: retention}
+}
+
+// TrashListResponse - постраничный ответ для GET /trash
+type TrashListResponse struct {
+	Items []db.TrashItem `json:"items"`
+	ListMeta
+}
+
+// ListTrash godoc
+// @Summary List deleted projects and vacancies
+// @Description Retrieve a page of the trash, most recently deleted first. Only items of projects where the user is an owner or manager are listed. Vacancies deleted together with their project are not listed separately: restoring the project restores them. purge_at tells when an item will be deleted permanently.
+// @Tags Trash
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param type query string false "Restrict results to one entity type" Enums(project, vacancy)
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param page query int false "Page number, starting from 1" default(1)
+// @Success 200 {object} TrashListResponse "Page of deleted items"
+// @Failure 400 {object} apierror.Problem "Invalid query parameters"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /trash [get]
+func (h *TrashHandler) ListTrash(c *gin.Context) {
+	principal, ok := auth.CurrentUser(c)
+	if !ok {
+		apierror.Respond(c, apierror.CodeUnauthorized, "")
+		return
+	}
+
+	params, err := parsePageParams(c)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, err.Error())
+		return
+	}
+	itemType := c.Query("type")
+	if itemType != "" && itemType != "project" && itemType != "vacancy" {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "type must be 'project' or 'vacancy'")
+		return
+	}
+
+	items, total, err := h.Trash.ListTrash(c.Request.Context(), repository.TrashFilter{
+		UserID: principal.UserID,
+		Type:   itemType,
+		Page:   params.Window(),
+	})
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve trash")
+		return
+	}
+	if h.Retention > 0 {
+		for i := range items {
+			purgeAt := items[i].DeletedAt.Add(h.Retention)
+			items[i].PurgeAt = &purgeAt
+		}
+	}
+
+	c.JSON(http.StatusOK, TrashListResponse{
+		Items:    items,
+		ListMeta: buildListMeta(c, params, total),
+	})
+}
+
+// RestoreProject godoc
+// @Summary Restore a deleted project
+// @Description Bring a project back from the trash together with the vacancies that were deleted with it. Requires the owner role in the project.
+// @Tags Trash
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Success 200 {object} database.Project "Restored project"
+// @Failure 400 {object} apierror.Problem "Invalid project ID format"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner of the project"
+// @Failure 404 {object} apierror.Problem "Project not found or not in the trash"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /projects/{id}/restore [post]
+func (h *TrashHandler) RestoreProject(c *gin.Context) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "project ID must be a positive integer")
+		return
+	}
+
+	// Восстановить проект может тот, кто мог его удалить
+	if !authorizeProject(c, h.Members, uint(projectID), ownerRoles...) {
+		return
+	}
+
+	project, err := h.Trash.RestoreProject(auditContext(c), uint(projectID))
+	switch {
+	case err == nil:
+		setETag(c, project.Version)
+		c.JSON(http.StatusOK, project)
+	case errors.Is(err, repository.ErrNotFound):
+		apierror.Respond(c, apierror.CodeProjectNotFound, "project is not in the trash")
+	default:
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to restore project")
+	}
+}
+
+// RestoreVacancy godoc
+// @Summary Restore a deleted vacancy
+// @Description Bring a vacancy back from the trash. If its project is in the trash too, restore the project instead. Requires the owner or manager role in the project.
+// @Tags Trash
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Vacancy ID"
+// @Success 200 {object} database.Vacancy "Restored vacancy"
+// @Failure 400 {object} apierror.Problem "Invalid vacancy ID format"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the project"
+// @Failure 404 {object} apierror.Problem "Vacancy not found in the trash"
+// @Failure 409 {object} apierror.Problem "The vacancy's project is in the trash"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /vacancies/{id}/restore [post]
+func (h *TrashHandler) RestoreVacancy(c *gin.Context) {
+	vacancyID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "vacancy ID must be a positive integer")
+		return
+	}
+
+	// Удаленная вакансия не видна через VacancyRepository, проект узнаем из корзины
+	item, err := h.Trash.GetTrashItem(c.Request.Context(), "vacancy", uint(vacancyID))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeVacancyNotFound, "vacancy is not in the trash")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to fetch vacancy")
+		}
+		return
+	}
+	if !authorizeProject(c, h.Members, item.ProjectID, editorRoles...) {
+		return
+	}
+
+	vacancy, err := h.Trash.RestoreVacancy(auditContext(c), uint(vacancyID))
+	switch {
+	case err == nil:
+		setETag(c, vacancy.Version)
+		c.JSON(http.StatusOK, vacancy)
+	case errors.Is(err, repository.ErrNotFound):
+		apierror.Respond(c, apierror.CodeVacancyNotFound, "vacancy is not in the trash")
+	case errors.Is(err, repository.ErrProjectDeleted):
+		apierror.Respond(c, apierror.CodeProjectDeleted, "restore project "+strconv.FormatUint(uint64(item.ProjectID), 10)+" instead")
+	default:
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to restore vacancy")
+	}
+}
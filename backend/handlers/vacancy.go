@@ -1,294 +1,435 @@
 package handlers
 
 import (
-	"database/sql" // Оставляем для sql.ErrNoRows
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
-
-	// "sync" // Удаляем, мьютекс больше не нужен
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/troodinc/trood-front-hackathon/apierror"
 	db "github.com/troodinc/trood-front-hackathon/database" // Импортируем пакет database как db
-	// "github.com/troodinc/trood-front-hackathon/models" // Удаляем несуществующий пакет models
+	"github.com/troodinc/trood-front-hackathon/repository"
 )
 
-// --- УДАЛЯЕМ ГЛОБАЛЬНЫЕ ПЕРЕМЕННЫЕ ДЛЯ ХРАНЕНИЯ В ПАМЯТИ ---
-/*
-var (
-	vacancies     = make(map[int]models.Vacancy)
-	nextVacancyID = 1
-	vacancyMutex  sync.Mutex
-)
-*/
+// VacancyHandler - обработчики маршрутов вакансий
+// (/vacancies и вложенных /projects/:id/vacancies)
+type VacancyHandler struct {
+	Vacancies repository.VacancyRepository
+	Members   repository.MemberRepository
+}
+
+// NewVacancyHandler создает обработчики вакансий поверх хранилища
+func NewVacancyHandler(vacancies repository.VacancyRepository, members repository.MemberRepository) *VacancyHandler {
+	return &VacancyHandler{Vacancies: vacancies, Members: members}
+}
 
 // GetVacancyByID godoc
 // @Summary Get a single vacancy by ID
-// @Description Retrieve details for a specific vacancy using its ID
-// @Tags vacancies // Исправлено с Vacancies на vacancies (лучше lowercase)
+// @Description Retrieve details for a specific vacancy using its ID. The ETag header holds the vacancy version; send it in If-None-Match to get 304 while the vacancy is unchanged.
+// @Tags vacancies
 // @Accept  json
 // @Produce  json
 // @Param   id   path      int  true  "Vacancy ID"
-// @Success 200 {object} database.Vacancy "Successfully retrieved vacancy" // Используем db.Vacancy
-// @Failure 400 {object} map[string]string "Invalid ID format"
-// @Failure 404 {object} map[string]string "Vacancy not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Param If-None-Match header string false "ETag from a previous response"
+// @Success 200 {object} database.Vacancy "Successfully retrieved vacancy"
+// @Header 200 {string} ETag "Vacancy version, e.g. \"3\""
+// @Success 304 "Vacancy has not changed since the given ETag"
+// @Failure 400 {object} apierror.Problem "Invalid ID format"
+// @Failure 404 {object} apierror.Problem "Vacancy not found"
+// @Failure 500 {object} apierror.Problem "Internal server error"
 // @Router /vacancies/{id} [get]
-func GetVacancyByID(c *gin.Context) {
+func (h *VacancyHandler) GetVacancyByID(c *gin.Context) {
 	idStr := c.Param("id")
 	// Используем ParseUint, так как ID обычно unsigned
 	id, err := strconv.ParseUint(idStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vacancy ID format"})
+		apierror.Respond(c, apierror.CodeInvalidParameter, "vacancy ID must be a positive integer")
 		return
 	}
 
-	var vacancy db.Vacancy // Используем тип из пакета db
-	query := "SELECT id, project_id, name, description, field, country, experience FROM vacancies WHERE id = ?"
-	err = db.DB.Get(&vacancy, query, uint(id)) // Приводим id к uint
-
+	vacancy, err := h.Vacancies.GetVacancy(c.Request.Context(), uint(id))
 	if err != nil {
-		if err == sql.ErrNoRows {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Vacancy not found"})
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeVacancyNotFound, "")
 		} else {
 			c.Error(err) // Логируем внутреннюю ошибку
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve vacancy"})
+			apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve vacancy")
 		}
 		return
 	}
+	if notModified(c, vacancy.Version) {
+		return
+	}
 
+	setETag(c, vacancy.Version)
 	c.JSON(http.StatusOK, vacancy)
 }
 
+// VacancyListResponse - постраничный ответ для GET /vacancies
+type VacancyListResponse struct {
+	Items []db.VacancyWithProject `json:"items"`
+	ListMeta
+}
+
+// SearchVacancies godoc
+// @Summary Search vacancies across all projects
+// @Description Retrieve a page of vacancies from all projects with optional filters. Each item includes the name and deadline of its project. Use sort=created_at&order=desc for the newest first.
+// @Tags vacancies
+// @Accept  json
+// @Produce  json
+// @Param field query string false "Filter by field" Enums(Design, Development, Marketing)
+// @Param country query string false "Filter by country (case-insensitive)"
+// @Param experience query string false "Filter by exact experience value"
+// @Param project_id query int false "Filter by project ID"
+// @Param status query string false "Comma-separated statuses to include, e.g. open,paused (default: all)"
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param page query int false "Page number, starting from 1" default(1)
+// @Param sort query string false "Sort field" Enums(id, name, field, country, project_name, deadline, created_at, updated_at) default(id)
+// @Param order query string false "Sort direction" Enums(asc, desc) default(asc)
+// @Success 200 {object} VacancyListResponse "Page of vacancies"
+// @Failure 400 {object} apierror.Problem "Invalid query parameters"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /vacancies [get]
+func (h *VacancyHandler) SearchVacancies(c *gin.Context) {
+	params, err := parsePageParams(c)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	sort, err := parseSortParams(c, repository.VacancySortFields, "id")
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	// Собираем фильтры
+	filter := repository.VacancyFilter{
+		Field:      c.Query("field"),
+		Country:    c.Query("country"),
+		Experience: c.Query("experience"),
+		Sort:       sort,
+		Page:       params.Window(),
+	}
+	if filter.Field != "" {
+		known := false
+		for _, f := range db.VacancyFields {
+			if strings.EqualFold(f, filter.Field) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			apierror.Respond(c, apierror.CodeInvalidParameter, "field must be one of: "+strings.Join(db.VacancyFields, ", "))
+			return
+		}
+	}
+	if raw := c.Query("project_id"); raw != "" {
+		projectID, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			apierror.Respond(c, apierror.CodeInvalidParameter, "project_id must be a positive integer")
+			return
+		}
+		filter.ProjectID = uint(projectID)
+	}
+	if filter.Statuses, err = parseVacancyStatuses(c); err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	vacancyList, total, err := h.Vacancies.SearchVacancies(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve vacancies")
+		return
+	}
+
+	c.JSON(http.StatusOK, VacancyListResponse{
+		Items:    vacancyList,
+		ListMeta: buildListMeta(c, params, total),
+	})
+}
+
 // GetVacancies godoc
 // @Summary Get all vacancies for a project
-// @Description Retrieve all vacancies for a given project by project ID
-// @Tags vacancies // Исправлено с Vacancies на vacancies
+// @Description Retrieve all vacancies for a given project by project ID, optionally only in the given statuses. Use sort=created_at&order=desc for the newest first.
+// @Tags vacancies
 // @Accept  json
 // @Produce  json
-// @Param id path int true "Project ID"                  // Это ID проекта из URL /projects/{id}/vacancies
-// @Success 200 {array} database.Vacancy "List of vacancies" // Используем db.Vacancy
-// @Failure 400 {object} map[string]string "Invalid project ID format"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Param id path int true "Project ID"
+// @Param status query string false "Comma-separated statuses to include, e.g. open,paused (default: all)"
+// @Param sort query string false "Sort field" Enums(id, name, field, country, created_at, updated_at) default(id)
+// @Param order query string false "Sort direction" Enums(asc, desc) default(asc)
+// @Success 200 {array} database.Vacancy "List of vacancies"
+// @Failure 400 {object} apierror.Problem "Invalid project ID format, status filter or sort"
+// @Failure 500 {object} apierror.Problem "Internal server error"
 // @Router /projects/{id}/vacancies [get]
-func GetVacancies(c *gin.Context) {
+func (h *VacancyHandler) GetVacancies(c *gin.Context) {
 	projectIDStr := c.Param("id") // Получаем ID проекта из URL
 	projectID, err := strconv.ParseUint(projectIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		apierror.Respond(c, apierror.CodeInvalidParameter, "project ID must be a positive integer")
 		return
 	}
 
-	var vacancyList []db.Vacancy // Используем слайс db.Vacancy
-	query := "SELECT id, project_id, name, description, field, country, experience FROM vacancies WHERE project_id = ?"
-	// Используем Select для получения нескольких строк
-	err = db.DB.Select(&vacancyList, query, uint(projectID))
+	statuses, err := parseVacancyStatuses(c)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, err.Error())
+		return
+	}
 
+	sort, err := parseSortParams(c, repository.ProjectVacancySortFields, "id")
 	if err != nil {
-		// Ошибку sql.ErrNoRows здесь обрабатывать не нужно, Select просто вернет пустой слайс
-		c.Error(err) // Логируем любую другую ошибку БД
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve vacancies"})
+		apierror.Respond(c, apierror.CodeInvalidParameter, err.Error())
 		return
 	}
 
-	// Если ошибок нет, vacancyList будет содержать найденные вакансии или будет пустым ([]),
-	// если для данного projectID вакансий нет. Gin корректно вернет пустой JSON массив.
+	vacancyList, err := h.Vacancies.ListProjectVacancies(c.Request.Context(), uint(projectID), statuses, sort)
+	if err != nil {
+		c.Error(err) // Логируем любую ошибку хранилища
+		apierror.Respond(c, apierror.CodeInternal, "Failed to retrieve vacancies")
+		return
+	}
+
+	// Если у проекта нет вакансий, vacancyList будет пустым ([]), Gin вернет пустой JSON массив.
 	c.JSON(http.StatusOK, vacancyList)
 }
 
 // CreateVacancy godoc
 // @Summary Create a new vacancy for a project
-// @Description Create a new vacancy by providing the vacancy details and the project ID
-// @Tags vacancies // Исправлено с Vacancies на vacancies
+// @Description Create a new vacancy by providing the vacancy details and the project ID. Requires the owner or manager role in the project.
+// @Tags vacancies
 // @Accept  json
 // @Produce  json
-// @Param id path int true "Project ID"                     // Это ID проекта из URL /projects/{id}/vacancies
-// @Param vacancy body database.Vacancy true "Vacancy data (ID and ProjectID can be omitted or 0)" // Используем db.Vacancy
-// @Success 201 {object} database.Vacancy "Vacancy created successfully" // Используем db.Vacancy
-// @Failure 400 {object} map[string]string "Invalid project ID format or invalid vacancy data"
-// @Failure 404 {object} map[string]string "Project not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param vacancy body database.Vacancy true "Vacancy data (ID and ProjectID can be omitted or 0; openings defaults to 1; status may be draft or open, default open)"
+// @Success 201 {object} database.Vacancy "Vacancy created successfully"
+// @Header 201 {string} ETag "Vacancy version"
+// @Failure 400 {object} apierror.Problem "Invalid project ID format or malformed JSON"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the project"
+// @Failure 404 {object} apierror.Problem "Project not found"
+// @Failure 422 {object} ValidationErrorResponse "Invalid vacancy fields"
+// @Failure 500 {object} apierror.Problem "Internal server error"
 // @Router /projects/{id}/vacancies [post]
-func CreateVacancy(c *gin.Context) {
+func (h *VacancyHandler) CreateVacancy(c *gin.Context) {
 	projectIDStr := c.Param("id")
 	projectID, err := strconv.ParseUint(projectIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		apierror.Respond(c, apierror.CodeInvalidParameter, "project ID must be a positive integer")
 		return
 	}
 
-	// Проверяем, существует ли проект (можно оптимизировать)
-	var projectExists bool
-	// TODO: Рассмотреть возможность вынести проверку существования проекта в middleware или отдельную функцию
-	err = db.DB.Get(&projectExists, "SELECT EXISTS(SELECT 1 FROM projects WHERE id = ?)", uint(projectID))
-    if err != nil && err != sql.ErrNoRows { // Проверяем на реальную ошибку БД
-        c.Error(err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check project existence"})
-        return
-    }
-    if !projectExists { // Если Get вернул false (т.е. EXISTS вернул 0 или NULL) или была ошибка ErrNoRows
-        c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
-        return
-    }
-
-
-	var newVacancy db.Vacancy // Используем db.Vacancy
-	if err := c.ShouldBindJSON(&newVacancy); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vacancy data format", "details": err.Error()})
+	var newVacancy db.Vacancy
+	if !bindJSON(c, &newVacancy) {
 		return
 	}
 
 	// Устанавливаем ID проекта из URL, игнорируя то, что могло прийти в JSON
 	newVacancy.ProjectID = uint(projectID)
+	newVacancy.ClosedAt = nil
+	if newVacancy.Openings == 0 {
+		newVacancy.Openings = 1 // по умолчанию ищем одного человека
+	}
+	switch newVacancy.Status {
+	case "":
+		newVacancy.Status = db.VacancyOpen
+	case db.VacancyDraft, db.VacancyOpen:
+	default:
+		respondValidationError(c, FieldError{Field: "status", Rule: "oneof", Reason: "must be one of: draft, open"})
+		return
+	}
 
-	// Выполняем INSERT в базу данных
-	query := `
-		INSERT INTO vacancies (project_id, name, description, field, country, experience)
-		VALUES (?, ?, ?, ?, ?, ?);
-	`
-	result, err := db.DB.Exec(query,
-		newVacancy.ProjectID, newVacancy.Name, newVacancy.Description,
-		newVacancy.Field, newVacancy.Country, newVacancy.Experience,
-	)
-	if err != nil {
-		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create vacancy"})
+	if !authorizeProject(c, h.Members, newVacancy.ProjectID, editorRoles...) {
 		return
 	}
 
-	// Получаем ID только что вставленной строки
-	lastID, err := result.LastInsertId()
-	if err != nil {
-		c.Error(err)
-		// Эта ошибка маловероятна с SQLite при успешном Exec, но лучше обработать
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve ID of created vacancy"})
+	if err := h.Vacancies.CreateVacancy(auditContext(c), &newVacancy); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeProjectNotFound, "")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to create vacancy")
+		}
 		return
 	}
-	newVacancy.ID = uint(lastID) // Присваиваем ID
 
 	// Возвращаем созданную вакансию с присвоенным ID
+	setETag(c, newVacancy.Version)
 	c.JSON(http.StatusCreated, newVacancy)
 }
 
 // EditVacancy godoc
 // @Summary Edit an existing vacancy
-// @Description Edit a vacancy by ID
-// @Tags vacancies // Исправлено с Vacancies на vacancies
+// @Description Edit a vacancy by ID. Requires the owner or manager role in the vacancy's project.
+// @Tags vacancies
 // @Accept  json
 // @Produce  json
-// @Param id path int true "Vacancy ID"                    // Это ID вакансии из URL /vacancies/{id}
-// @Param vacancy body database.Vacancy true "Updated vacancy data (ID and ProjectID in body are ignored)" // Используем db.Vacancy
-// @Success 200 {object} database.Vacancy "Vacancy updated successfully" // Используем db.Vacancy
-// @Failure 400 {object} map[string]string "Invalid vacancy ID format or invalid vacancy data"
-// @Failure 404 {object} map[string]string "Vacancy not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Param id path int true "Vacancy ID"
+// @Param If-Match header string false "ETag of the vacancy being edited; required if the server enforces it"
+// @Param vacancy body database.Vacancy true "Updated vacancy data (ID, ProjectID, status and version in body are ignored; openings > 0 replaces the number of openings, 0 keeps it; the status does not change)"
+// @Success 200 {object} database.Vacancy "Vacancy updated successfully"
+// @Header 200 {string} ETag "New vacancy version"
+// @Failure 400 {object} apierror.Problem "Invalid vacancy ID format, malformed JSON or If-Match"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the vacancy's project"
+// @Failure 404 {object} apierror.Problem "Vacancy not found"
+// @Failure 412 {object} apierror.Problem "Vacancy changed since the ETag in If-Match"
+// @Failure 422 {object} ValidationErrorResponse "Invalid values in the fields the request changes"
+// @Failure 428 {object} apierror.Problem "If-Match is required but missing"
+// @Failure 500 {object} apierror.Problem "Internal server error"
 // @Router /vacancies/{id} [put]
-func EditVacancy(c *gin.Context) {
+func (h *VacancyHandler) EditVacancy(c *gin.Context) {
 	vacancyIDStr := c.Param("id")
 	vacancyID, err := strconv.ParseUint(vacancyIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vacancy ID format"})
+		apierror.Respond(c, apierror.CodeInvalidParameter, "vacancy ID must be a positive integer")
 		return
 	}
 
-	var updatedVacancyData db.Vacancy // Используем db.Vacancy
-	if err := c.ShouldBindJSON(&updatedVacancyData); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vacancy data format", "details": err.Error()})
+	current, ok := h.authorizeVacancy(c, uint(vacancyID))
+	if !ok {
 		return
 	}
 
-	// Выполняем UPDATE в базе данных
-	query := `
-		UPDATE vacancies SET
-			name = ?,
-			description = ?,
-			field = ?,
-			country = ?,
-			experience = ?
-			-- project_id обычно не меняется при редактировании вакансии, но можно добавить, если нужно
-		WHERE id = ?;
-	`
-	result, err := db.DB.Exec(query,
-		updatedVacancyData.Name,
-		updatedVacancyData.Description,
-		updatedVacancyData.Field,
-		updatedVacancyData.Country,
-		updatedVacancyData.Experience,
-		uint(vacancyID), // ID вакансии для условия WHERE
-	)
-
-	if err != nil {
-		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update vacancy"})
+	var updatedVacancyData db.Vacancy
+	if !bindUpdateJSON(c, current, &updatedVacancyData) {
 		return
 	}
+	updatedVacancyData.ID = current.ID // ID берем из URL
+
+	h.saveVacancy(c, &updatedVacancyData)
+}
 
-	// Проверяем, была ли запись реально обновлена
-	rowsAffected, err := result.RowsAffected()
+// PatchVacancy godoc
+// @Summary Partially update a vacancy
+// @Description Update only the fields present in the body, following JSON Merge Patch (RFC 7396): omitted fields keep their values and null clears a field. ID, project and status cannot be changed here. Openings change only when present in the patch; as in PUT, the status does not change. Requires the owner or manager role in the vacancy's project.
+// @Tags vacancies
+// @Accept  json
+// @Accept  application/merge-patch+json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Vacancy ID"
+// @Param If-Match header string false "ETag of the vacancy being edited; required if the server enforces it"
+// @Param vacancy body database.Vacancy true "Fields to change"
+// @Success 200 {object} database.Vacancy "Vacancy updated successfully"
+// @Header 200 {string} ETag "New vacancy version"
+// @Failure 400 {object} apierror.Problem "Invalid vacancy ID format, malformed patch or If-Match"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the vacancy's project"
+// @Failure 404 {object} apierror.Problem "Vacancy not found"
+// @Failure 412 {object} apierror.Problem "Vacancy changed since the ETag in If-Match"
+// @Failure 415 {object} apierror.Problem "Body is neither application/json nor application/merge-patch+json"
+// @Failure 422 {object} ValidationErrorResponse "Invalid values in the fields the patch changes"
+// @Failure 428 {object} apierror.Problem "If-Match is required but missing"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /vacancies/{id} [patch]
+func (h *VacancyHandler) PatchVacancy(c *gin.Context) {
+	vacancyID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		// Маловероятно получить ошибку здесь, если Exec прошел, но все же
-		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check rows affected after update"})
+		apierror.Respond(c, apierror.CodeInvalidParameter, "vacancy ID must be a positive integer")
+		return
+	}
+	current, ok := h.authorizeVacancy(c, uint(vacancyID))
+	if !ok {
 		return
 	}
 
-	if rowsAffected == 0 {
-		// Если ни одна строка не была затронута, значит вакансии с таким ID не существует
-		c.JSON(http.StatusNotFound, gin.H{"error": "Vacancy not found"})
+	var vacancy db.Vacancy
+	patch, ok := bindMergePatch(c, current, &vacancy)
+	if !ok {
 		return
 	}
+	vacancy.ID = current.ID
+	if _, ok := patch["openings"]; !ok {
+		vacancy.Openings = 0 // 0 - оставить число мест как есть
+	}
+	h.saveVacancy(c, &vacancy)
+}
 
-	// Если обновление прошло успешно, вернем обновленные данные
-    // Мы можем либо вернуть то, что пришло в updatedVacancyData (присвоив ID),
-    // либо сделать еще один SELECT, чтобы получить актуальные данные из БД.
-    // Вернем пришедшие данные с правильным ID для простоты.
-    updatedVacancyData.ID = uint(vacancyID)
-    // ProjectID мы не меняли, но в updatedVacancyData его может не быть или он 0.
-    // Если нужно вернуть актуальный ProjectID, нужно делать SELECT.
-    // Пока не будем усложнять.
+// saveVacancy сохраняет изменения вакансии и отвечает вакансией, заново прочитанной
+// из хранилища: project_id, число мест и closed_at в теле запроса могли отсутствовать.
+// Ожидаемую версию берет из If-Match, а не из тела.
+func (h *VacancyHandler) saveVacancy(c *gin.Context, vacancy *db.Vacancy) {
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+	vacancy.Version = version
+
+	ctx := auditContext(c)
+	if err := h.Vacancies.UpdateVacancy(ctx, vacancy); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			// Вакансии с таким ID не существует
+			apierror.Respond(c, apierror.CodeVacancyNotFound, "")
+		} else if errors.Is(err, repository.ErrVersionMismatch) {
+			respondPreconditionFailed(c)
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to update vacancy")
+		}
+		return
+	}
 
-	c.JSON(http.StatusOK, updatedVacancyData)
+	saved, err := h.Vacancies.GetVacancy(ctx, vacancy.ID)
+	if err != nil {
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to fetch vacancy")
+		return
+	}
+	setETag(c, saved.Version)
+	c.JSON(http.StatusOK, saved)
 }
 
 // DeleteVacancy godoc
 // @Summary Delete a vacancy by ID
-// @Description Delete a vacancy by its ID
-// @Tags vacancies // Исправлено с Vacancies на vacancies
+// @Description Move a vacancy to the trash by its ID; it can be restored with POST /vacancies/{id}/restore until the trash is purged. Requires the owner or manager role in the vacancy's project.
+// @Tags vacancies
 // @Accept  json
 // @Produce  json
-// @Param id path int true "Vacancy ID" // Это ID вакансии из URL /vacancies/{id}
-// @Success 204 "Vacancy deleted successfully" // Нет тела ответа
-// @Failure 400 {object} map[string]string "Invalid vacancy ID format"
-// @Failure 404 {object} map[string]string "Vacancy not found"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Param id path int true "Vacancy ID"
+// @Param If-Match header string false "ETag of the vacancy being deleted; required if the server enforces it"
+// @Success 204 "Vacancy deleted successfully"
+// @Failure 400 {object} apierror.Problem "Invalid vacancy ID format or If-Match"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the vacancy's project"
+// @Failure 404 {object} apierror.Problem "Vacancy not found"
+// @Failure 412 {object} apierror.Problem "Vacancy changed since the ETag in If-Match"
+// @Failure 428 {object} apierror.Problem "If-Match is required but missing"
+// @Failure 500 {object} apierror.Problem "Internal server error"
 // @Router /vacancies/{id} [delete]
-func DeleteVacancy(c *gin.Context) {
+func (h *VacancyHandler) DeleteVacancy(c *gin.Context) {
 	vacancyIDStr := c.Param("id")
 	vacancyID, err := strconv.ParseUint(vacancyIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vacancy ID format"})
+		apierror.Respond(c, apierror.CodeInvalidParameter, "vacancy ID must be a positive integer")
 		return
 	}
 
-	// Выполняем DELETE в базе данных
-	query := "DELETE FROM vacancies WHERE id = ?"
-	result, err := db.DB.Exec(query, uint(vacancyID))
-	if err != nil {
-		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete vacancy"})
+	if _, ok := h.authorizeVacancy(c, uint(vacancyID)); !ok {
 		return
 	}
-
-	// Проверяем, была ли строка удалена
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check rows affected after delete"})
+	version, ok := ifMatchVersion(c)
+	if !ok {
 		return
 	}
 
-	if rowsAffected == 0 {
-		// Если ни одна строка не была затронута, вакансии с таким ID не было
-		c.JSON(http.StatusNotFound, gin.H{"error": "Vacancy not found"})
+	if err := h.Vacancies.DeleteVacancy(auditContext(c), uint(vacancyID), version); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			// Вакансии с таким ID не было
+			apierror.Respond(c, apierror.CodeVacancyNotFound, "")
+		} else if errors.Is(err, repository.ErrVersionMismatch) {
+			respondPreconditionFailed(c)
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to delete vacancy")
+		}
 		return
 	}
 
@@ -296,218 +437,121 @@ func DeleteVacancy(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// authorizeVacancy проверяет право изменять вакансию через роль в ее проекте
+// и возвращает вакансию. Если вакансии нет, отвечает 404 и возвращает false.
+func (h *VacancyHandler) authorizeVacancy(c *gin.Context, vacancyID uint) (db.Vacancy, bool) {
+	vacancy, err := h.Vacancies.GetVacancy(c.Request.Context(), vacancyID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Respond(c, apierror.CodeVacancyNotFound, "")
+		} else {
+			c.Error(err)
+			apierror.Respond(c, apierror.CodeInternal, "Failed to fetch vacancy")
+		}
+		return db.Vacancy{}, false
+	}
+	return vacancy, authorizeProject(c, h.Members, vacancy.ProjectID, editorRoles...)
+}
+
+// parseVacancyStatuses читает фильтр status - список статусов через запятую
+func parseVacancyStatuses(c *gin.Context) ([]db.VacancyStatus, error) {
+	raw := c.Query("status")
+	if raw == "" {
+		return nil, nil
+	}
+	statuses := []db.VacancyStatus{}
+	for _, name := range strings.Split(raw, ",") {
+		status := db.VacancyStatus(strings.TrimSpace(name))
+		if !slices.Contains(db.VacancyStatuses, status) {
+			return nil, fmt.Errorf("status must be a comma-separated list of: %s", joinNames(db.VacancyStatuses, ", "))
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// ChangeVacancyStatusRequest - тело PATCH /vacancies/:id/status
+type ChangeVacancyStatusRequest struct {
+	Status db.VacancyStatus `json:"status" binding:"required" enums:"draft,open,paused,closed" example:"paused"`
+}
 
-// package handlers
-
-// import (
-// 	"database/sql" // <--- Нужен для sql.ErrNoRows при работе с базой данных
-// 	"net/http"     // <--- Нужен для статус-кодов HTTP
-// 	"strconv"      // <--- Нужен для конвертации ID из строки
-// 	"sync"         // <--- ВОЗМОЖНО, НЕ НУЖЕН! (Если ты не используешь мьютексы в памяти)
-
-// 	"github.com/gin-gonic/gin"                              // <--- Фреймворк Gin
-// 	db "github.com/troodinc/trood-front-hackathon/database" // <--- Пакет для DB соединения и, возможно, моделей
-// 	"github.com/troodinc/trood-front-hackathon/models"      // <--- ПРОБЛЕМА: Импорт пакета models, которого, скорее всего, нет
-// )
-
-// var (
-// 	vacancies     = make(map[int]models.Vacancy)
-// 	nextVacancyID = 1
-// 	vacancyMutex  sync.Mutex
-// )
-
-// // GetVacancies godoc
-// // @Summary Get all vacancies for a project
-// // @Description Retrieve all vacancies for a given project by project ID
-// // @Tags Vacancies
-// // @Accept  json
-// // @Produce  json
-// // @Param id path int true "Project ID"
-// // @Success 200 {array} models.Vacancy
-// // @Failure 404 {object} map[string]interface{} "Project not found"
-// // @Router /projects/{id}/vacancies [get]
-
-// func GetVacancyByID(c *gin.Context) {
-// 	// 1. Получаем ID из параметра пути
-// 	idStr := c.Param("id")
-
-// 	// 2. Конвертируем ID из строки в число
-// 	id, err := strconv.ParseUint(idStr, 10, 64)
-// 	if err != nil {
-// 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vacancy ID format"})
-// 		return
-// 	}
-
-// 	// 3. Создаем переменную для хранения результата
-// 	var vacancy db.Vacancy // Используем тип Vacancy из пакета db
-
-// 	// --- >>> ЗАПРОС К БАЗЕ ДАННЫХ (ЗАМЕНА СТАРОЙ ЛОГИКИ) <<< ---
-// 	// SQL-запрос для выбора одной вакансии по ID
-// 	query := "SELECT id, project_id, name, description, field, country, experience FROM vacancies WHERE id = ?"
-// 	// Используем db.DB.Get для выполнения запроса и маппинга результата в структуру vacancy
-// 	err = db.DB.Get(&vacancy, query, uint(id)) // Передаем сам запрос и ID в качестве аргумента
-// 	// --- <<< КОНЕЦ ЗАПРОСА К БД >>> ---
-
-// 	// 4. Проверяем результат запроса
-// 	if err != nil {
-// 		// Проверяем, была ли ошибка "запись не найдена"
-// 		if err == sql.ErrNoRows { // <--- Используем sql.ErrNoRows
-// 			c.JSON(http.StatusNotFound, gin.H{"error": "Vacancy not found"})
-// 		} else {
-// 			// Логируем любую другую ошибку БД на сервере
-// 			c.Error(err) // Логируем ошибку для отладки
-// 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve vacancy"})
-// 		}
-// 		return // Выходим после обработки ошибки
-// 	}
-
-// 	// 5. Если ошибок нет, вакансия найдена - возвращаем ее
-// 	c.JSON(http.StatusOK, vacancy)
-// }
-// func GetVacancies(c *gin.Context) {
-// 	projectIDStr := c.Param("id")
-// 	projectID, err := strconv.Atoi(projectIDStr)
-// 	if err != nil {
-// 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
-// 		return
-// 	}
-
-// 	vacancyMutex.Lock()
-// 	defer vacancyMutex.Unlock()
-
-// 	var vacancyList []models.Vacancy
-// 	for _, vacancy := range vacancies {
-// 		if vacancy.ProjectID == projectID {
-// 			vacancyList = append(vacancyList, vacancy)
-// 		}
-// 	}
-
-// 	// Ensure an empty array is returned if no vacancies are found
-// 	if len(vacancyList) == 0 {
-// 		c.JSON(http.StatusOK, []models.Vacancy{})
-// 		return
-// 	}
-
-// 	c.JSON(http.StatusOK, vacancyList)
-// }
-
-// // CreateVacancy godoc
-// // @Summary Create a new vacancy for a project
-// // @Description Create a new vacancy by providing the vacancy details and the project ID
-// // @Tags Vacancies
-// // @Accept  json
-// // @Produce  json
-// // @Param id path int true "Project ID"
-// // @Param vacancy body models.Vacancy true "Vacancy data"
-// // @Success 201 {object} models.Vacancy
-// // @Failure 404 {object} map[string]interface{} "Project not found"
-// // @Router /projects/{id}/vacancies [post]
-// func CreateVacancy(c *gin.Context) {
-// 	projectIDStr := c.Param("id")
-// 	projectID, err := strconv.Atoi(projectIDStr)
-// 	if err != nil {
-// 		c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Invalid project ID"})
-// 		return
-// 	}
-
-// 	var vacancy models.Vacancy
-// 	if err := c.ShouldBindJSON(&vacancy); err != nil {
-// 		c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Invalid input"})
-// 		return
-// 	}
-
-// 	vacancyMutex.Lock()
-// 	defer vacancyMutex.Unlock()
-
-// 	projectFound := false
-// 	for _, project := range projects {
-// 		if project.ID == projectID {
-// 			projectFound = true
-// 			break
-// 		}
-// 	}
-
-// 	if !projectFound {
-// 		c.JSON(http.StatusNotFound, map[string]interface{}{"error": "Project not found"})
-// 		return
-// 	}
-
-// 	vacancy.ID = nextVacancyID
-// 	nextVacancyID++
-// 	vacancy.ProjectID = projectID
-// 	vacancies[vacancy.ID] = vacancy
-
-// 	c.JSON(http.StatusCreated, vacancy)
-// }
-
-// // EditVacancy godoc
-// // @Summary Edit an existing vacancy
-// // @Description Edit a vacancy by ID
-// // @Tags Vacancies
-// // @Accept  json
-// // @Produce  json
-// // @Param id path int true "Vacancy ID"
-// // @Param vacancy body models.Vacancy true "Updated vacancy data"
-// // @Success 200 {object} models.Vacancy
-// // @Failure 404 {object} map[string]interface{} "Vacancy not found"
-// // @Router /vacancies/{id} [put]
-// func EditVacancy(c *gin.Context) {
-// 	idStr := c.Param("id")
-// 	id, err := strconv.Atoi(idStr)
-// 	if err != nil {
-// 		c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Invalid vacancy ID"})
-// 		return
-// 	}
-
-// 	var vacancy models.Vacancy
-// 	if err := c.ShouldBindJSON(&vacancy); err != nil {
-// 		c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Invalid input"})
-// 		return
-// 	}
-
-// 	vacancyMutex.Lock()
-// 	defer vacancyMutex.Unlock()
-
-// 	if existingVacancy, exists := vacancies[id]; exists {
-// 		existingVacancy.Name = vacancy.Name
-// 		existingVacancy.Description = vacancy.Description
-// 		existingVacancy.Field = vacancy.Field
-// 		existingVacancy.Country = vacancy.Country
-// 		existingVacancy.Experience = vacancy.Experience
-// 		vacancies[id] = existingVacancy
-
-// 		c.JSON(http.StatusOK, existingVacancy)
-// 	} else {
-// 		c.JSON(http.StatusNotFound, map[string]interface{}{"error": "Vacancy not found"})
-// 	}
-// }
-
-// // DeleteVacancy godoc
-// // @Summary Delete a vacancy by ID
-// // @Description Delete a vacancy by its ID
-// // @Tags Vacancies
-// // @Accept  json
-// // @Produce  json
-// // @Param id path int true "Vacancy ID"
-// // @Success 204 {object} map[string]interface{} "No Content"
-// // @Failure 404 {object} map[string]interface{} "Vacancy not found"
-// // @Router /vacancies/{id} [delete]
-// func DeleteVacancy(c *gin.Context) {
-// 	idStr := c.Param("id")
-// 	id, err := strconv.Atoi(idStr)
-// 	if err != nil {
-// 		c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "Invalid vacancy ID"})
-// 		return
-// 	}
-
-// 	vacancyMutex.Lock()
-// 	defer vacancyMutex.Unlock()
-
-// 	if _, exists := vacancies[id]; exists {
-// 		delete(vacancies, id)
-
-// 		c.JSON(http.StatusNoContent, map[string]interface{}{"message": "Vacancy deleted"})
-// 	} else {
-// 		c.JSON(http.StatusNotFound, map[string]interface{}{"error": "Vacancy not found"})
-// 	}
-// }
+// InvalidVacancyTransitionResponse - тело ответа 409 при недопустимой смене статуса вакансии
+type InvalidVacancyTransitionResponse struct {
+	apierror.Problem
+	From    db.VacancyStatus   `json:"from" example:"closed"`
+	To      db.VacancyStatus   `json:"to" example:"paused"`
+	Allowed []db.VacancyStatus `json:"allowed"`
+}
+
+// ChangeVacancyStatus godoc
+// @Summary Change the status of a vacancy
+// @Description Move a vacancy through its lifecycle. Allowed changes: draft → open or closed; open → paused or closed; paused → open or closed; closed → open; expired → open or closed. Vacancies become expired automatically once their project's deadline has passed. Opening requires at least one opening and a deadline that has not passed. Only open vacancies accept applications. Requires the owner or manager role in the vacancy's project.
+// @Tags vacancies
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param id path int true "Vacancy ID"
+// @Param If-Match header string false "ETag of the vacancy being changed; required if the server enforces it"
+// @Param status body ChangeVacancyStatusRequest true "New status"
+// @Success 200 {object} database.Vacancy "Status changed"
+// @Header 200 {string} ETag "New vacancy version"
+// @Failure 400 {object} apierror.Problem "Invalid vacancy ID format, malformed JSON or If-Match"
+// @Failure 401 {object} apierror.Problem "Missing, invalid or expired access token"
+// @Failure 403 {object} apierror.Problem "Not an owner or manager of the vacancy's project"
+// @Failure 404 {object} apierror.Problem "Vacancy not found"
+// @Failure 409 {object} InvalidVacancyTransitionResponse "Transition not allowed, no openings left or project deadline passed"
+// @Failure 412 {object} apierror.Problem "Vacancy changed since the ETag in If-Match"
+// @Failure 422 {object} ValidationErrorResponse "Missing or unknown status"
+// @Failure 428 {object} apierror.Problem "If-Match is required but missing"
+// @Failure 500 {object} apierror.Problem "Internal server error"
+// @Router /vacancies/{id}/status [patch]
+func (h *VacancyHandler) ChangeVacancyStatus(c *gin.Context) {
+	vacancyID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, apierror.CodeInvalidParameter, "vacancy ID must be a positive integer")
+		return
+	}
+
+	var input ChangeVacancyStatusRequest
+	if !bindJSON(c, &input) {
+		return
+	}
+	if !slices.Contains(db.VacancyStatuses, input.Status) || input.Status == db.VacancyExpired {
+		respondValidationError(c, FieldError{Field: "status", Rule: "oneof", Reason: "must be one of: draft, open, paused, closed"})
+		return
+	}
+
+	if _, ok := h.authorizeVacancy(c, uint(vacancyID)); !ok {
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	vacancy, err := h.Vacancies.ChangeVacancyStatus(auditContext(c), uint(vacancyID), input.Status, version, db.Today())
+	var invalid *repository.InvalidVacancyTransitionError
+	switch {
+	case err == nil:
+		setETag(c, vacancy.Version)
+		c.JSON(http.StatusOK, vacancy)
+	case errors.Is(err, repository.ErrNotFound):
+		apierror.Respond(c, apierror.CodeVacancyNotFound, "")
+	case errors.Is(err, repository.ErrVersionMismatch):
+		respondPreconditionFailed(c)
+	case errors.As(err, &invalid):
+		apierror.Write(c, &InvalidVacancyTransitionResponse{
+			Problem: apierror.New(apierror.CodeInvalidStatusTransition, "cannot change status from "+string(invalid.From)+" to "+string(invalid.To)),
+			From:    invalid.From,
+			To:      invalid.To,
+			Allowed: invalid.From.NextStatuses(),
+		})
+	case errors.Is(err, repository.ErrNoOpenings):
+		apierror.Respond(c, apierror.CodeNoOpenings, "set openings with PUT /vacancies/{id} before reopening the vacancy")
+	case errors.Is(err, repository.ErrDeadlinePassed):
+		apierror.Respond(c, apierror.CodeDeadlinePassed, "move the project deadline before reopening the vacancy")
+	default:
+		c.Error(err)
+		apierror.Respond(c, apierror.CodeInternal, "Failed to change vacancy status")
+	}
+}
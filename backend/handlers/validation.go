@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/troodinc/trood-front-hackathon/apierror"
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// FieldError - ошибка валидации одного поля тела запроса
+type FieldError struct {
+	Field  string `json:"field" example:"name"`
+	Rule   string `json:"rule" example:"required"`
+	Reason string `json:"reason" example:"is required"`
+}
+
+// ValidationErrorResponse - тело ответа 422: все некорректные поля сразу,
+// чтобы форма могла показать ошибки рядом с каждым полем
+type ValidationErrorResponse struct {
+	apierror.Problem
+	Fields []FieldError `json:"fields"`
+}
+
+// experiencePattern - требуемый опыт: "no experience", "1 year", "3+ years", "1-3 years"
+var experiencePattern = regexp.MustCompile(`^(?i:no experience|\d{1,2}(?:\+|-\d{1,2})? years?)$`)
+
+// Правила валидации задаются тегами binding у моделей и тел запросов.
+// Здесь регистрируем собственные правила в валидаторе gin.
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	// В ошибках указываем поле так, как оно называется в JSON
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	// Дата проверяется как строка: нулевая дата - пустая строка, и required срабатывает
+	v.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		return field.Interface().(db.Date).String()
+	}, db.Date{})
+	v.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+		return strings.TrimSpace(fl.Field().String()) != ""
+	})
+	v.RegisterValidation("experience", func(fl validator.FieldLevel) bool {
+		return experiencePattern.MatchString(fl.Field().String())
+	})
+}
+
+// fieldCheck - проверка тела запроса, которую нельзя выразить тегом binding
+// (например, дедлайн в прошлом). Возвращает nil, если поле корректно.
+type fieldCheck func() *FieldError
+
+// bindJSON разбирает тело запроса в dest и проверяет правила из тегов binding,
+// а затем проверки checks. Нарушения правил и значения неверного типа отдаются
+// как 422 со списком полей, синтаксически некорректный JSON - как 400.
+// Возвращает false, если ответ уже отправлен.
+func bindJSON(c *gin.Context, dest any, checks ...fieldCheck) bool {
+	return bindJSONOver(c, nil, dest, checks...)
+}
+
+// bindUpdateJSON - bindJSON для изменения записи current: нарушения правил в полях,
+// которые остались как в current, ошибкой не считаются. Так записи, сохраненные до
+// появления правил (например, со страной "Ukraine"), можно править, не трогая такие поля.
+func bindUpdateJSON(c *gin.Context, current, dest any, checks ...fieldCheck) bool {
+	return bindJSONOver(c, current, dest, checks...)
+}
+
+// bindJSONOver - общая часть bindJSON и bindUpdateJSON; current == nil - создание записи
+func bindJSONOver(c *gin.Context, current, dest any, checks ...fieldCheck) bool {
+	// Тело сохраняется в контексте: оно понадобится, чтобы найти поле с ошибкой типа
+	err := c.ShouldBindBodyWithJSON(dest)
+	var validationErrs validator.ValidationErrors
+	if err != nil && !errors.As(err, &validationErrs) {
+		body, _ := c.Get(gin.BodyBytesKey)
+		bodyBytes, _ := body.([]byte)
+		respondBindError(c, bodyBytes, dest, err)
+		return false
+	}
+	// Документ разобран целиком, поэтому проверки checks видят все поля,
+	// и клиент получает нарушения тегов и проверок одним ответом
+	fields := ruleFieldErrors(validationErrs)
+	if current != nil {
+		fields = changedFieldErrors(current, dest, fields)
+	}
+	for _, check := range checks {
+		if fe := check(); fe != nil {
+			fields = append(fields, *fe)
+		}
+	}
+	if len(fields) > 0 {
+		respondValidationError(c, fields...)
+		return false
+	}
+	return true
+}
+
+// respondBindError отвечает на ошибку разбора или проверки документа body, разобранного в dest
+func respondBindError(c *gin.Context, body []byte, dest any, err error) {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		respondValidationError(c, ruleFieldErrors(validationErrs)...)
+		return
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		if field := typeErrorField(body, dest, typeErr); field != "" {
+			respondValidationError(c, FieldError{Field: field, Rule: "type", Reason: typeReason(typeErr.Type)})
+			return
+		}
+	}
+	apierror.Respond(c, apierror.CodeMalformedRequest, err.Error())
+}
+
+// ruleFieldErrors переводит нарушения правил из тегов binding в ошибки полей
+func ruleFieldErrors(validationErrs validator.ValidationErrors) []FieldError {
+	fields := make([]FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		fields = append(fields, FieldError{Field: fe.Field(), Rule: fe.Tag(), Reason: ruleReason(fe)})
+	}
+	return fields
+}
+
+// changedFieldErrors оставляет из fields ошибки только тех полей, значение которых
+// в updated отличается от current. Поля сравниваются в том виде, в каком они
+// отдаются в API; если записи не удается сравнить, fields возвращаются как есть.
+func changedFieldErrors(current, updated any, fields []FieldError) []FieldError {
+	currentFields, err := jsonObject(current)
+	if err != nil {
+		return fields
+	}
+	updatedFields, err := jsonObject(updated)
+	if err != nil {
+		return fields
+	}
+	changed := fields[:0:0]
+	for _, fe := range fields {
+		if !reflect.DeepEqual(currentFields[fe.Field], updatedFields[fe.Field]) {
+			changed = append(changed, fe)
+		}
+	}
+	return changed
+}
+
+// jsonObject - поля записи в том виде, в каком она отдается в API
+func jsonObject(value any) (map[string]any, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	return fields, json.Unmarshal(encoded, &fields)
+}
+
+// typeErrorField возвращает поле, значение которого не удалось разобрать.
+// encoding/json указывает поле не всегда (например, для ошибок из UnmarshalJSON),
+// поэтому в этом случае разбираем по отдельности каждое поле верхнего уровня нужного типа.
+func typeErrorField(body []byte, dest any, typeErr *json.UnmarshalTypeError) string {
+	if typeErr.Field != "" {
+		return typeErr.Field
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return ""
+	}
+	t := reflect.TypeOf(dest)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	for _, f := range reflect.VisibleFields(t) {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		value, ok := raw[name]
+		if !ok || (f.Type != typeErr.Type && !(f.Type.Kind() == reflect.Pointer && f.Type.Elem() == typeErr.Type)) {
+			continue
+		}
+		if err := json.Unmarshal(value, reflect.New(f.Type).Interface()); err != nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// respondValidationError отвечает 422 с перечнем некорректных полей.
+// Используется и для проверок, которые выполняются уже после разбора тела.
+func respondValidationError(c *gin.Context, fields ...FieldError) {
+	apierror.Write(c, &ValidationErrorResponse{
+		Problem: apierror.New(apierror.CodeValidationFailed, "see fields for details"),
+		Fields:  fields,
+	})
+}
+
+// ruleReason - понятное описание нарушенного правила
+func ruleReason(fe validator.FieldError) string {
+	unit := ""
+	if fe.Kind() == reflect.String {
+		unit = " characters"
+	}
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "notblank":
+		return "must not be blank"
+	case "max", "lte":
+		return "must be at most " + fe.Param() + unit
+	case "min", "gte":
+		return "must be at least " + fe.Param() + unit
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "iso3166_1_alpha2":
+		return "must be an ISO 3166-1 alpha-2 country code, e.g. DE"
+	case "experience":
+		return `must look like "no experience", "1 year", "3+ years" or "1-3 years"`
+	case "email":
+		return "must be a valid email address"
+	default:
+		return "is invalid"
+	}
+}
+
+// typeReason - описание ожидаемого типа для значения, которое не удалось разобрать
+func typeReason(t reflect.Type) string {
+	if t == reflect.TypeOf(db.Date{}) {
+		return "must be a date in YYYY-MM-DD or DD.MM.YYYY format"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "must be a string"
+	case reflect.Bool:
+		return "must be a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "must be an integer"
+	case reflect.Float32, reflect.Float64:
+		return "must be a number"
+	default:
+		return "has an invalid type"
+	}
+}
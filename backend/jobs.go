@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+	"github.com/troodinc/trood-front-hackathon/repository"
+)
+
+// runEvery выполняет job сразу при запуске и затем каждые interval, пока не отменен ctx
+func runEvery(ctx context.Context, interval time.Duration, job func()) {
+	job()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			job()
+		}
+	}
+}
+
+// runVacancyExpiry переводит в expired вакансии проектов с прошедшим дедлайном
+func runVacancyExpiry(ctx context.Context, vacancies repository.VacancyRepository, interval time.Duration) {
+	runEvery(ctx, interval, func() {
+		expired, err := vacancies.ExpireVacancies(ctx, db.Today())
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Printf("Failed to expire vacancies: %v", err)
+		case expired > 0:
+			log.Printf("Expired %d vacancies past their project deadline", expired)
+		}
+	})
+}
+
+// runTrashPurge окончательно удаляет проекты и вакансии, пролежавшие в корзине дольше retention
+func runTrashPurge(ctx context.Context, trash repository.TrashRepository, retention, interval time.Duration) {
+	runEvery(ctx, interval, func() {
+		purged, err := trash.PurgeTrash(ctx, time.Now().UTC().Add(-retention))
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Printf("Failed to purge trash: %v", err)
+		case purged.Projects > 0 || purged.Vacancies > 0:
+			log.Printf("Purged %d projects and %d vacancies from the trash", purged.Projects, purged.Vacancies)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/troodinc/trood-front-hackathon/apierror"
+	"github.com/troodinc/trood-front-hackathon/config"
+)
+
+// setupLogging настраивает slog по конфигурации. Стандартный пакет log
+// после slog.SetDefault тоже пишет через этот обработчик (с уровнем INFO),
+// поэтому формат и уровень применяются ко всем сообщениям приложения.
+func setupLogging(cfg config.LogConfig) {
+	options := &slog.HandlerOptions{Level: cfg.SlogLevel()}
+	var handler slog.Handler
+	if cfg.Format == "json" {
+		handler = slog.NewJSONHandler(os.Stderr, options)
+	} else {
+		handler = slog.NewTextHandler(os.Stderr, options)
+	}
+	slog.SetDefault(slog.New(handler))
+
+	// Отладочный вывод Gin (список маршрутов и т.п.) нужен только на уровне debug
+	if cfg.Level == "debug" {
+		gin.SetMode(gin.DebugMode)
+	} else {
+		gin.SetMode(gin.ReleaseMode)
+	}
+}
+
+// requestLogger - замена gin.Logger(), которая пишет журнал запросов через slog
+func requestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+		if c.Request.URL.RawQuery != "" {
+			path += "?" + c.Request.URL.RawQuery
+		}
+
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		attrs := []any{
+			"method", c.Request.Method,
+			"path", path,
+			"status", status,
+			"latency", time.Since(start),
+			"client_ip", c.ClientIP(),
+			"request_id", apierror.RequestIDFrom(c),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, "errors", c.Errors.String())
+		}
+		slog.Log(c.Request.Context(), level, "request", attrs...)
+	}
+}
@@ -1,16 +1,23 @@
 package main
 
 import (
+	"context"
 	"log"
-	"time" // Понадобится для cors.Config
+	"os"
+	"strings"
+	"sync"
 
 	"github.com/gin-contrib/cors" // <<< 1. Импортируем пакет CORS
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"github.com/troodinc/trood-front-hackathon/apierror"
+	"github.com/troodinc/trood-front-hackathon/auth"
+	"github.com/troodinc/trood-front-hackathon/config"
 	db "github.com/troodinc/trood-front-hackathon/database"
 	_ "github.com/troodinc/trood-front-hackathon/docs" // Импорт для автогенерации Swagger
 	"github.com/troodinc/trood-front-hackathon/handlers"
+	"github.com/troodinc/trood-front-hackathon/repository"
 )
 
 // @title Trood Front Hackathon API
@@ -18,34 +25,79 @@ import (
 // @description This is the API documentation for the Trood Front Hackathon. Welcome to hell.
 // @host localhost:8080
 // @BasePath /
-
+//
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Access token from /auth/login in the form "Bearer <token>"
 func main() {
-	// Инициализация базы данных и, возможно, начальных данных
-	db.InitDatabase()
-	handlers.InitProjects() // Оставляем, если это нужно для инициализации
+	// Настройки: config.yaml, переменные окружения и флаги (см. пакет config)
+	cfg, args, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	setupLogging(cfg.Log)
+
+	// Служебные команды (migrate ...) выполняются вместо запуска сервера
+	if runCommand(cfg, args) {
+		return
+	}
 
-	// Создаем экземпляр Gin с логгером и recovery middleware по умолчанию
-	r := gin.Default()
+	// Инициализация базы данных и хранилища поверх нее
+	db.InitDatabase(cfg.Database)
+	store := repository.NewSQLStore(db.DB)
+	if cfg.Seed.Enabled {
+		handlers.InitProjects(store) // Заполняем начальными данными, если проектов еще нет
+	}
 
-	// --- 2. Настройка CORS для локальной разработки ---
+	// Политика удаления проектов с вакансиями уже проверена в config.Validate
+	deletePolicy, _ := repository.ParseDeletePolicy(cfg.Projects.DeletePolicy)
+	log.Printf("Project delete policy: %s", deletePolicy)
+
+	projectHandler := handlers.NewProjectHandler(store, store, store, deletePolicy)
+	vacancyHandler := handlers.NewVacancyHandler(store, store)
+	memberHandler := handlers.NewMemberHandler(store)
+	applicationHandler := handlers.NewApplicationHandler(store, store, store)
+	teamHandler := handlers.NewTeamHandler(store, store, store)
+	searchHandler := handlers.NewSearchHandler(store)
+	trashHandler := handlers.NewTrashHandler(store, store, cfg.Trash.Retention())
+	auditHandler := handlers.NewAuditHandler(store)
+	revisionHandler := handlers.NewRevisionHandler(store, store, store, store)
+	healthHandler := handlers.NewHealthHandler(buildInfo())
+	tokens := newTokenService(cfg.Auth)
+	authHandler := handlers.NewAuthHandler(store, tokens, cfg.Auth.RefreshTTL)
+	// Чтение открыто всем, изменения требуют действительного access-токена,
+	// а права в конкретном проекте проверяют сами обработчики по роли участника
+	requireAuth := auth.RequireAuth(tokens)
+	// If-Match для изменения проектов и вакансий: обязателен или по желанию клиента
+	ifMatch := handlers.IfMatchPolicy(cfg.Server.RequireIfMatch)
+
+	// Создаем экземпляр Gin: идентификатор запроса, журнал запросов через slog
+	// и recovery middleware, отвечающий в формате problem+json
+	r := gin.New()
+	r.Use(apierror.RequestID(), requestLogger(), apierror.Recovery())
+
+	// --- Настройка CORS ---
 	// Создаем конфигурацию CORS. Используем DefaultConfig как основу.
+	// Источники и время кэширования preflight-запроса (OPTIONS) берутся из конфигурации.
 	corsConfig := cors.DefaultConfig()
-
-	// !!! ВАЖНО: Разрешаем запросы ТОЛЬКО от твоего локального фронтенда Vite
-	// corsConfig.AllowOrigins = []string{"http://localhost:5173"}
-	corsConfig.AllowOrigins = []string{
-    "http://localhost:5173",
-    "http://65.108.87.81:5173",
+	if cfg.CORS.AllowsAll() {
+		corsConfig.AllowAllOrigins = true
+	} else {
+		corsConfig.AllowOrigins = cfg.CORS.AllowOrigins
 	}
 
 	// Оставляем разрешенные методы по умолчанию (GET, POST, PUT, PATCH, DELETE, HEAD, OPTIONS)
 	// corsConfig.AllowMethods = []string{"GET", "POST", ...}
 
-	// Оставляем разрешенные заголовки по умолчанию (Origin, Content-Type, Accept и т.д.)
-	// corsConfig.AllowHeaders = []string{"Origin", "Content-Type", ...}
+	// К заголовкам по умолчанию (Origin, Content-Length, Content-Type) добавляем access-токен
+	// и условные заголовки с версией записи
+	corsConfig.AllowHeaders = append(corsConfig.AllowHeaders, "Authorization", "If-Match", "If-None-Match")
 
-	// Указываем, как долго браузер может кэшировать результат preflight-запроса (OPTIONS)
-	corsConfig.MaxAge = 12 * time.Hour
+	corsConfig.MaxAge = cfg.CORS.MaxAge
+	// Фронтенд показывает идентификатор запроса в сообщении об ошибке
+	// и возвращает ETag записи в If-Match при ее изменении
+	corsConfig.ExposeHeaders = append(corsConfig.ExposeHeaders, apierror.RequestIDHeader, "ETag")
 
 	// !!! Применяем CORS middleware ко всем маршрутам ДО их определения
 	r.Use(cors.New(corsConfig))
@@ -58,15 +110,36 @@ func main() {
 	// Маршруты для Проектов
 	projectRoutes := r.Group("/projects") // Группируем роуты для проектов
 	{
-		projectRoutes.GET("", handlers.GetProjects)       // GET /projects
-		projectRoutes.POST("", handlers.CreateProject)    // POST /projects
-		projectRoutes.GET("/:id", handlers.GetProjectByID) // GET /projects/123
-		projectRoutes.PUT("/:id", handlers.EditProject)    // PUT /projects/123
-		projectRoutes.DELETE("/:id", handlers.DeleteProject) // DELETE /projects/123
+		projectRoutes.GET("", projectHandler.GetProjects)                                // GET /projects
+		projectRoutes.POST("", requireAuth, projectHandler.CreateProject)                // POST /projects
+		projectRoutes.GET("/:id", projectHandler.GetProjectByID)                         // GET /projects/123
+		projectRoutes.PUT("/:id", requireAuth, ifMatch, projectHandler.EditProject)      // PUT /projects/123
+		projectRoutes.PATCH("/:id", requireAuth, ifMatch, projectHandler.PatchProject)   // PATCH /projects/123
+		projectRoutes.DELETE("/:id", requireAuth, ifMatch, projectHandler.DeleteProject) // DELETE /projects/123
+
+		// Восстановление из корзины
+		projectRoutes.POST("/:id/restore", requireAuth, trashHandler.RestoreProject) // POST /projects/123/restore
+
+		// История содержимого проекта и откат к ревизии
+		projectRoutes.GET("/:id/revisions", requireAuth, revisionHandler.ListProjectRevisions)                 // GET /projects/123/revisions
+		projectRoutes.POST("/:id/revisions/:rev/restore", requireAuth, revisionHandler.RestoreProjectRevision) // POST /projects/123/revisions/2/restore
 
 		// Вложенные маршруты для Вакансий конкретного проекта
-		projectRoutes.GET("/:id/vacancies", handlers.GetVacancies)    // GET /projects/123/vacancies
-		projectRoutes.POST("/:id/vacancies", handlers.CreateVacancy) // POST /projects/123/vacancies
+		projectRoutes.GET("/:id/vacancies", vacancyHandler.GetVacancies)                // GET /projects/123/vacancies
+		projectRoutes.POST("/:id/vacancies", requireAuth, vacancyHandler.CreateVacancy) // POST /projects/123/vacancies
+
+		// Участники проекта и их роли
+		projectRoutes.GET("/:id/members", requireAuth, memberHandler.GetMembers)               // GET /projects/123/members
+		projectRoutes.PUT("/:id/members/:user_id", requireAuth, memberHandler.SetMember)       // PUT /projects/123/members/7
+		projectRoutes.DELETE("/:id/members/:user_id", requireAuth, memberHandler.RemoveMember) // DELETE /projects/123/members/7
+
+		// Все отклики на вакансии проекта
+		projectRoutes.GET("/:id/applications", requireAuth, applicationHandler.GetProjectApplications) // GET /projects/123/applications
+
+		// Команда проекта: кого уже наняли
+		projectRoutes.GET("/:id/team", teamHandler.GetTeam)                                     // GET /projects/123/team
+		projectRoutes.POST("/:id/team", requireAuth, teamHandler.AddTeamMember)                 // POST /projects/123/team
+		projectRoutes.DELETE("/:id/team/:member_id", requireAuth, teamHandler.RemoveTeamMember) // DELETE /projects/123/team/5
 	}
 
 	// Маршруты для Вакансий (независимые от проекта, если такие есть по ТЗ?)
@@ -74,67 +147,84 @@ func main() {
 	// Поэтому создаем отдельную группу
 	vacancyRoutes := r.Group("/vacancies")
 	{
-		vacancyRoutes.GET("/:id", handlers.GetVacancyByID) // GET /vacancies/456
-		vacancyRoutes.PUT("/:id", handlers.EditVacancy)    // PUT /vacancies/456
-		vacancyRoutes.DELETE("/:id", handlers.DeleteVacancy) // DELETE /vacancies/456
+		vacancyRoutes.GET("", vacancyHandler.SearchVacancies)                                        // GET /vacancies?field=Design&country=...
+		vacancyRoutes.GET("/:id", vacancyHandler.GetVacancyByID)                                     // GET /vacancies/456
+		vacancyRoutes.PUT("/:id", requireAuth, ifMatch, vacancyHandler.EditVacancy)                  // PUT /vacancies/456
+		vacancyRoutes.PATCH("/:id", requireAuth, ifMatch, vacancyHandler.PatchVacancy)               // PATCH /vacancies/456
+		vacancyRoutes.DELETE("/:id", requireAuth, ifMatch, vacancyHandler.DeleteVacancy)             // DELETE /vacancies/456
+		vacancyRoutes.POST("/:id/restore", requireAuth, trashHandler.RestoreVacancy)                 // POST /vacancies/456/restore
+		vacancyRoutes.PATCH("/:id/status", requireAuth, ifMatch, vacancyHandler.ChangeVacancyStatus) // PATCH /vacancies/456/status
+
+		// История содержимого вакансии и откат к ревизии
+		vacancyRoutes.GET("/:id/revisions", requireAuth, revisionHandler.ListVacancyRevisions)                 // GET /vacancies/456/revisions
+		vacancyRoutes.POST("/:id/revisions/:rev/restore", requireAuth, revisionHandler.RestoreVacancyRevision) // POST /vacancies/456/revisions/2/restore
+
+		// Отклики на вакансию: откликнуться может кто угодно, смотреть - владельцы и менеджеры проекта
+		vacancyRoutes.POST("/:id/applications", applicationHandler.Apply)                              // POST /vacancies/456/applications
+		vacancyRoutes.GET("/:id/applications", requireAuth, applicationHandler.GetVacancyApplications) // GET /vacancies/456/applications
 	}
-	// --- Конец Маршрутов ---
-
-
-	// --- Запуск сервера ---
-	port := "8080"
-	// Обновляем лог, чтобы было видно, что CORS настроен
-	log.Printf("Server starting on http://localhost:%s with CORS enabled for origin: %s", port, corsConfig.AllowOrigins[0])
 
-	// Запускаем сервер Gin
-	if err := r.Run(":" + port); err != nil {
-		log.Fatalf("Server failed to start: %v", err)
+	// Рассмотрение откликов
+	applicationRoutes := r.Group("/applications")
+	{
+		applicationRoutes.GET("/:id", requireAuth, applicationHandler.GetApplicationByID)             // GET /applications/789
+		applicationRoutes.PUT("/:id/status", requireAuth, applicationHandler.ChangeApplicationStatus) // PUT /applications/789/status
+	}
+	// Регистрация и вход пользователей
+	authRoutes := r.Group("/auth")
+	{
+		authRoutes.POST("/register", authHandler.Register) // POST /auth/register
+		authRoutes.POST("/login", authHandler.Login)       // POST /auth/login
+		authRoutes.POST("/refresh", authHandler.Refresh)   // POST /auth/refresh
+		authRoutes.POST("/logout", authHandler.Logout)     // POST /auth/logout
+		authRoutes.GET("/me", requireAuth, authHandler.Me) // GET /auth/me
 	}
-}
-
-
-// package main
-
-// import (
-// 	"log"
-
-// 	"github.com/gin-gonic/gin"
-// 	swaggerFiles "github.com/swaggo/files"
-// 	ginSwagger "github.com/swaggo/gin-swagger"
-// 	db "github.com/troodinc/trood-front-hackathon/database"
-// 	_ "github.com/troodinc/trood-front-hackathon/docs"
-// 	"github.com/troodinc/trood-front-hackathon/handlers"
-// )
-
-// // @title Trood Front Hackathon API
-// // @version 1.0
-// // @description This is the API documentation for the Trood Front Hackathon. Welcome to hell.
-// // @host localhost:8080
-// // @BasePath /
 
-// func main() {
-// 	db.InitDatabase()
-// 	handlers.InitProjects()
+	// Корзина: удаленные проекты и вакансии, которые еще можно восстановить
+	r.GET("/trash", requireAuth, trashHandler.ListTrash) // GET /trash?type=project
 
-// 	r := gin.Default()
+	// Журнал аудита изменений проектов и вакансий
+	r.GET("/audit", requireAuth, auditHandler.ListAuditEvents) // GET /audit?entity_type=vacancy&entity_id=456
 
-// 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// Полнотекстовый поиск по проектам и вакансиям
+	r.GET("/search", searchHandler.SearchAll) // GET /search?q=designer
 
-// 	r.GET("/projects", handlers.GetProjects)
-// 	r.GET("/projects/:id", handlers.GetProjectByID)
-// 	r.POST("/projects", handlers.CreateProject)
-// 	r.PUT("/projects/:id", handlers.EditProject)
-// 	r.DELETE("/projects/:id", handlers.DeleteProject)
+	// Проверки состояния для Docker и балансировщиков
+	r.GET("/healthz", healthHandler.Healthz) // процесс жив
+	r.GET("/readyz", healthHandler.Readyz)   // база доступна, миграции применены, не идет остановка
+	r.GET("/status", healthHandler.Status)   // версия, время работы, состояние базы
 
-// 	r.GET("/projects/:id/vacancies", handlers.GetVacancies)
-// 	r.POST("/projects/:id/vacancies", handlers.CreateVacancy)
-// 	r.PUT("/vacancies/:id", handlers.EditVacancy)
-// 	r.DELETE("/vacancies/:id", handlers.DeleteVacancy)
+	// --- Конец Маршрутов ---
 
-// 	port := "8080"
-// 	log.Println("Server running on http://localhost:" + port)
+	// --- Запуск сервера ---
+	// Обновляем лог, чтобы было видно, что CORS настроен
+	log.Printf("Server starting on %s with CORS enabled for origins: %s", cfg.Server.Addr(), strings.Join(cfg.CORS.AllowOrigins, ", "))
+
+	// Фоновые задачи работают, пока работает сервер
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	var jobs sync.WaitGroup
+	if cfg.Vacancies.ExpiryInterval > 0 {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			runVacancyExpiry(jobsCtx, store, cfg.Vacancies.ExpiryInterval)
+		}()
+	}
+	if cfg.Trash.RetentionDays > 0 {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			runTrashPurge(jobsCtx, store, cfg.Trash.Retention(), cfg.Trash.PurgeInterval)
+		}()
+	}
 
-// 	if err := r.Run(":" + port); err != nil {
-// 		log.Fatalf("Server failed to start: %v", err)
-// 	}
-// }
+	// Запускаем сервер и ждем сигнала остановки; соединение с базой
+	// закрываем только после того, как завершились все активные запросы и фоновые задачи
+	err = serve(r, healthHandler, cfg.Server)
+	stopJobs()
+	jobs.Wait()
+	db.CloseDatabase()
+	if err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+}
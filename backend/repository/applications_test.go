@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+func TestCreateApplication(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s store) {
+		ctx := context.Background()
+		project := createProject(t, s, "Hiring", nil)
+		open := createVacancy(t, s, project.ID, "Open")
+		paused := createVacancy(t, s, project.ID, "Paused")
+		if _, err := s.ChangeVacancyStatus(ctx, paused.ID, db.VacancyPaused, 0, db.Today()); err != nil {
+			t.Fatalf("ChangeVacancyStatus: %v", err)
+		}
+
+		tests := []struct {
+			name      string
+			vacancyID uint
+			email     string
+			wantErr   error
+		}{
+			{"open vacancy", open.ID, "jamie@example.com", nil},
+			{"same email again", open.ID, "jamie@example.com", ErrAlreadyExists},
+			{"paused vacancy", paused.ID, "jamie@example.com", ErrVacancyNotOpen},
+			{"missing vacancy", 999, "jamie@example.com", ErrNotFound},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				application := db.Application{VacancyID: tt.vacancyID, Name: "Jamie", Email: tt.email}
+				err := s.CreateApplication(ctx, &application)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("CreateApplication error = %v, want %v", err, tt.wantErr)
+				}
+				if err != nil {
+					return
+				}
+				if application.Status != db.ApplicationSubmitted || application.ProjectID != project.ID {
+					t.Errorf("application = %s in project %d, want submitted in project %d", application.Status, application.ProjectID, project.ID)
+				}
+			})
+		}
+	})
+}
+
+func TestChangeApplicationStatus(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s store) {
+		ctx := context.Background()
+		project := createProject(t, s, "Hiring", nil)
+		vacancy := createVacancy(t, s, project.ID, "Designer")
+
+		tests := []struct {
+			name string
+			// path - переходы, которые выполняются до проверяемого
+			path    []db.ApplicationStatus
+			to      db.ApplicationStatus
+			wantErr bool
+		}{
+			{name: "submitted to reviewing", to: db.ApplicationReviewing},
+			{name: "reviewing to interview", path: []db.ApplicationStatus{db.ApplicationReviewing}, to: db.ApplicationInterview},
+			{name: "interview to rejected", path: []db.ApplicationStatus{db.ApplicationReviewing, db.ApplicationInterview}, to: db.ApplicationRejected},
+			{name: "submitted to hired", to: db.ApplicationHired, wantErr: true},
+			{name: "interview to reviewing", path: []db.ApplicationStatus{db.ApplicationReviewing, db.ApplicationInterview}, to: db.ApplicationReviewing, wantErr: true},
+			{name: "rejected to offer", path: []db.ApplicationStatus{db.ApplicationRejected}, to: db.ApplicationOffer, wantErr: true},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				application := db.Application{VacancyID: vacancy.ID, Name: "Jamie", Email: tt.name + "@example.com"}
+				if err := s.CreateApplication(ctx, &application); err != nil {
+					t.Fatalf("CreateApplication: %v", err)
+				}
+				for _, status := range tt.path {
+					var err error
+					if application, err = s.ChangeApplicationStatus(ctx, application.ID, &db.ApplicationEvent{ToStatus: status}); err != nil {
+						t.Fatalf("ChangeApplicationStatus(%s): %v", status, err)
+					}
+				}
+
+				changed, err := s.ChangeApplicationStatus(ctx, application.ID, &db.ApplicationEvent{ToStatus: tt.to, Note: tt.name})
+				if tt.wantErr {
+					var transition *InvalidTransitionError
+					if !errors.As(err, &transition) || transition.From != application.Status || transition.To != tt.to {
+						t.Fatalf("error = %v, want transition error from %s to %s", err, application.Status, tt.to)
+					}
+				} else if err != nil || changed.Status != tt.to {
+					t.Fatalf("ChangeApplicationStatus = %s, %v; want %s", changed.Status, err, tt.to)
+				}
+
+				// История начинается с подачи отклика и содержит только выполненные переходы
+				events, err := s.ListApplicationEvents(ctx, application.ID)
+				if err != nil {
+					t.Fatalf("ListApplicationEvents: %v", err)
+				}
+				wantEvents := len(tt.path) + 1
+				if !tt.wantErr {
+					wantEvents++
+				}
+				if len(events) != wantEvents {
+					t.Fatalf("history has %d events, want %d", len(events), wantEvents)
+				}
+				last := events[len(events)-1]
+				if !tt.wantErr && (last.FromStatus != application.Status || last.ToStatus != tt.to || last.Note != tt.name) {
+					t.Errorf("last event = %s -> %s %q, want %s -> %s %q", last.FromStatus, last.ToStatus, last.Note, application.Status, tt.to, tt.name)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// Actor - кто вносит изменение. Хранилище записывает его в журнал аудита
+// вместе с каждым изменением проекта или вакансии.
+type Actor struct {
+	UserID    *uint  // пусто для изменений без пользователя (начальные данные, фоновые задачи)
+	RequestID string // X-Request-ID запроса, в котором сделано изменение
+}
+
+type actorKey struct{}
+
+// WithActor возвращает контекст, изменения в котором записываются в журнал от имени actor
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// actorFrom достает автора изменения из контекста; без WithActor - пустой Actor
+func actorFrom(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	return actor
+}
+
+// changeStamp возвращает время и автора изменения для колонок updated_at и updated_by
+func changeStamp(ctx context.Context) (time.Time, *uint) {
+	return time.Now().UTC(), actorFrom(ctx).UserID
+}
+
+// newTimestamps - отметки только что созданной записи
+func newTimestamps(ctx context.Context) db.Timestamps {
+	now, actorID := changeStamp(ctx)
+	return db.Timestamps{CreatedAt: now, UpdatedAt: now, CreatedBy: actorID, UpdatedBy: actorID}
+}
+
+// newAuditEvent готовит запись журнала об изменении записи entityType.
+// before и after - состояние записи до и после изменения; nil при создании и удалении.
+// Возвращает false, если изменение ничего не поменяло и записывать нечего.
+func newAuditEvent(ctx context.Context, action db.AuditAction, entityType string, entityID, projectID uint, before, after any) (db.AuditEvent, bool, error) {
+	actor := actorFrom(ctx)
+	event := db.AuditEvent{
+		ActorID:    actor.UserID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		ProjectID:  projectID,
+		RequestID:  actor.RequestID,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	var err error
+	switch {
+	case before == nil:
+		event.After, err = json.Marshal(after)
+	case after == nil:
+		event.Before, err = json.Marshal(before)
+	default:
+		var changed bool
+		event.Before, event.After, changed, err = auditDiff(before, after)
+		if err == nil && !changed {
+			return event, false, nil
+		}
+	}
+	return event, true, err
+}
+
+// auditSkippedFields меняются при каждом изменении и повторяют время и автора самого события,
+// поэтому в различия не попадают
+var auditSkippedFields = []string{"updated_at", "updated_by"}
+
+// auditDiff оставляет в JSON-представлениях before и after только различающиеся поля.
+// changed - нашлось ли хоть одно различие.
+func auditDiff(before, after any) (db.JSON, db.JSON, bool, error) {
+	beforeFields, err := jsonFields(before)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	afterFields, err := jsonFields(after)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	changedBefore, changedAfter := map[string]any{}, map[string]any{}
+	for name := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			beforeFields[name] = nil
+		}
+	}
+	for _, name := range auditSkippedFields {
+		delete(beforeFields, name)
+	}
+	for name, value := range beforeFields {
+		if !reflect.DeepEqual(value, afterFields[name]) {
+			changedBefore[name] = value
+			changedAfter[name] = afterFields[name]
+		}
+	}
+
+	encodedBefore, err := json.Marshal(changedBefore)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	encodedAfter, err := json.Marshal(changedAfter)
+	return encodedBefore, encodedAfter, len(changedBefore) > 0, err
+}
+
+// jsonFields - поля записи в том виде, в каком она отдается в API
+func jsonFields(value any) (map[string]any, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]any{}
+	return fields, json.Unmarshal(encoded, &fields)
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+func TestAuditDiff(t *testing.T) {
+	type record struct {
+		Name      string  `json:"name"`
+		Openings  int     `json:"openings"`
+		ClosedAt  *string `json:"closed_at,omitempty"`
+		UpdatedAt string  `json:"updated_at"`
+		UpdatedBy *uint   `json:"updated_by"`
+	}
+	closedAt, actor := "2026-01-02", uint(7)
+
+	tests := []struct {
+		name        string
+		before      record
+		after       record
+		wantBefore  string
+		wantAfter   string
+		wantChanged bool
+	}{
+		{
+			name:       "changed fields only",
+			before:     record{Name: "Designer", Openings: 2},
+			after:      record{Name: "Designer", Openings: 1},
+			wantBefore: `{"openings":2}`, wantAfter: `{"openings":1}`, wantChanged: true,
+		},
+		{
+			name:       "added field is null before",
+			before:     record{Name: "Designer"},
+			after:      record{Name: "Designer", ClosedAt: &closedAt},
+			wantBefore: `{"closed_at":null}`, wantAfter: `{"closed_at":"2026-01-02"}`, wantChanged: true,
+		},
+		{
+			name:       "updated_at and updated_by are skipped",
+			before:     record{Name: "Designer", UpdatedAt: "yesterday"},
+			after:      record{Name: "Designer", UpdatedAt: "today", UpdatedBy: &actor},
+			wantBefore: `{}`, wantAfter: `{}`,
+		},
+		{
+			name:       "nothing changed",
+			before:     record{Name: "Designer", Openings: 1},
+			after:      record{Name: "Designer", Openings: 1},
+			wantBefore: `{}`, wantAfter: `{}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, after, changed, err := auditDiff(tt.before, tt.after)
+			if err != nil {
+				t.Fatalf("auditDiff: %v", err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			assertJSON(t, "before", before, tt.wantBefore)
+			assertJSON(t, "after", after, tt.wantAfter)
+		})
+	}
+}
+
+// assertJSON сравнивает JSON got и want без учета порядка полей
+func assertJSON(t *testing.T, name string, got db.JSON, want string) {
+	t.Helper()
+	var gotValue, wantValue any
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("%s = %s, want %s", name, got, want)
+	}
+}
+
+func TestAuditEvents(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s store) {
+		userID := createUser(t, s, "owner@example.com")
+		outsiderID := createUser(t, s, "outsider@example.com")
+		ctx := WithActor(context.Background(), Actor{UserID: &userID, RequestID: "req-1"})
+
+		project := createProject(t, s, "Audited", func(p *db.Project) { p.OwnerID = &userID })
+		vacancy := createVacancy(t, s, project.ID, "Designer")
+
+		renamed := project
+		renamed.Name = "Audited v2"
+		if err := s.UpdateProject(ctx, &renamed); err != nil {
+			t.Fatalf("UpdateProject: %v", err)
+		}
+		// Повторное сохранение без изменений в журнал не попадает
+		renamed.Version = 0
+		if err := s.UpdateProject(ctx, &renamed); err != nil {
+			t.Fatalf("UpdateProject without changes: %v", err)
+		}
+		member := db.TeamMember{ProjectID: project.ID, VacancyID: &vacancy.ID, Name: "Jamie"}
+		if err := s.AddTeamMember(ctx, &member); err != nil {
+			t.Fatalf("AddTeamMember: %v", err)
+		}
+		overdue := createProject(t, s, "Overdue", func(p *db.Project) {
+			p.OwnerID = &userID
+			p.Deadline = daysFromToday(-1)
+		})
+		createVacancy(t, s, overdue.ID, "Expiring")
+		if expired, err := s.ExpireVacancies(ctx, db.Today()); err != nil || expired != 1 {
+			t.Fatalf("ExpireVacancies = %d, %v; want 1", expired, err)
+		}
+
+		tests := []struct {
+			name       string
+			filter     AuditFilter
+			wantBefore string
+			wantAfter  string
+			// wantActor - ожидаемый автор; nil для изменений фоновой задачи
+			wantActor *uint
+			// wantClosedAt - в after есть время закрытия; оно не сравнивается с wantAfter
+			wantClosedAt bool
+		}{
+			{
+				name:       "project rename",
+				filter:     AuditFilter{Action: db.AuditUpdate, EntityType: "project", ProjectID: project.ID},
+				wantBefore: `{"name":"Audited","version":1}`, wantAfter: `{"name":"Audited v2","version":2}`,
+				wantActor: &userID,
+			},
+			{
+				name:       "hire",
+				filter:     AuditFilter{Action: db.AuditUpdate, EntityType: "vacancy", EntityID: vacancy.ID},
+				wantBefore: `{"openings":1,"status":"open","closed_at":null,"version":1}`,
+				wantAfter:  `{"openings":0,"status":"closed","version":2}`,
+				wantActor:  &userID, wantClosedAt: true,
+			},
+			{
+				name:       "expiry",
+				filter:     AuditFilter{Action: db.AuditUpdate, EntityType: "vacancy", ProjectID: overdue.ID},
+				wantBefore: `{"status":"open","version":1}`, wantAfter: `{"status":"expired","version":2}`,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.filter.UserID = userID
+				tt.filter.Page.Limit = 10
+				events, total, err := s.ListAuditEvents(ctx, tt.filter)
+				if err != nil {
+					t.Fatalf("ListAuditEvents: %v", err)
+				}
+				if total != 1 {
+					t.Fatalf("ListAuditEvents found %d events, want 1", total)
+				}
+				event := events[0]
+				if !reflect.DeepEqual(event.ActorID, tt.wantActor) {
+					t.Errorf("actor = %v, want %v", event.ActorID, tt.wantActor)
+				}
+				if tt.wantActor != nil && event.RequestID != "req-1" {
+					t.Errorf("request_id = %q, want req-1", event.RequestID)
+				}
+				after := map[string]any{}
+				if err := json.Unmarshal(event.After, &after); err != nil {
+					t.Fatalf("after: %v", err)
+				}
+				if tt.wantClosedAt {
+					if after["closed_at"] == nil {
+						t.Errorf("after = %s, want closed_at", event.After)
+					}
+					delete(after, "closed_at")
+				}
+				encodedAfter, _ := json.Marshal(after)
+				assertJSON(t, "before", event.Before, tt.wantBefore)
+				assertJSON(t, "after", encodedAfter, tt.wantAfter)
+			})
+		}
+
+		// Журнал виден только владельцам и менеджерам проектов
+		if _, total, err := s.ListAuditEvents(ctx, AuditFilter{UserID: outsiderID, Page: Page{Limit: 10}}); err != nil || total != 0 {
+			t.Errorf("ListAuditEvents for an outsider = %d events, %v; want 0", total, err)
+		}
+	})
+}
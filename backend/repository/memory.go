@@ -0,0 +1,538 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// MemoryStore хранит проекты, вакансии и пользователей в памяти процесса.
+// Подходит для тестов обработчиков: не требует базы и миграций.
+type MemoryStore struct {
+	mu               sync.Mutex
+	projects         map[uint]db.Project
+	vacancies        map[uint]db.Vacancy
+	nextProjectID    uint
+	nextVacancyID    uint
+	team             map[uint]db.TeamMember
+	nextTeamMemberID uint
+	memoryUsers
+	members map[memberKey]db.ProjectMember
+	memoryApplications
+	audit       []db.AuditEvent
+	nextAuditID uint
+
+	revisions      []db.Revision
+	nextRevisionID uint
+}
+
+// NewMemoryStore создает пустое хранилище в памяти
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		projects:           make(map[uint]db.Project),
+		vacancies:          make(map[uint]db.Vacancy),
+		nextProjectID:      1,
+		nextVacancyID:      1,
+		team:               make(map[uint]db.TeamMember),
+		nextTeamMemberID:   1,
+		memoryUsers:        newMemoryUsers(),
+		members:            make(map[memberKey]db.ProjectMember),
+		memoryApplications: newMemoryApplications(),
+		nextAuditID:        1,
+		nextRevisionID:     1,
+	}
+}
+
+// paginate вырезает страницу из уже отсортированного списка
+func paginate[T any](items []T, page Page) []T {
+	if page.Offset >= len(items) {
+		return []T{}
+	}
+	end := len(items)
+	if page.Limit > 0 && page.Offset+page.Limit < end {
+		end = page.Offset + page.Limit
+	}
+	return items[page.Offset:end]
+}
+
+// sortByKey сортирует список по строковому ключу, при равенстве ключей - по id
+func sortByKey[T any](items []T, desc bool, key func(T) string, id func(T) uint) {
+	sort.SliceStable(items, func(i, j int) bool {
+		ki, kj := key(items[i]), key(items[j])
+		if ki == kj {
+			if desc {
+				return id(items[i]) > id(items[j])
+			}
+			return id(items[i]) < id(items[j])
+		}
+		if desc {
+			return ki > kj
+		}
+		return ki < kj
+	})
+}
+
+// timeKey - ключ сортировки по времени: строки фиксированной длины сравниваются в хронологическом порядке
+func timeKey(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000000000")
+}
+
+// activeProject возвращает проект, если он есть и не удален в корзину; вызывается под m.mu
+func (m *MemoryStore) activeProject(id uint) (db.Project, bool) {
+	project, ok := m.projects[id]
+	return project, ok && project.DeletedAt == nil
+}
+
+// activeVacancy возвращает вакансию, если она есть и не удалена в корзину; вызывается под m.mu
+func (m *MemoryStore) activeVacancy(id uint) (db.Vacancy, bool) {
+	vacancy, ok := m.vacancies[id]
+	return vacancy, ok && vacancy.DeletedAt == nil
+}
+
+// ListProjects реализует ProjectRepository
+func (m *MemoryStore) ListProjects(ctx context.Context, filter ProjectFilter) ([]db.Project, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	projects := []db.Project{}
+	for _, p := range m.projects {
+		switch {
+		case p.DeletedAt != nil,
+			!filter.IncludeArchived && p.ArchivedAt != nil,
+			filter.Experience != "" && p.Experience != filter.Experience,
+			!filter.DeadlineAfter.IsZero() && p.Deadline.Before(filter.DeadlineAfter.Time),
+			!filter.DeadlineBefore.IsZero() && p.Deadline.After(filter.DeadlineBefore.Time):
+			continue
+		}
+		projects = append(projects, p)
+	}
+
+	key := func(p db.Project) string { return "" }
+	switch filter.Sort.Field {
+	case "name":
+		key = func(p db.Project) string { return p.Name }
+	case "deadline":
+		key = func(p db.Project) string { return p.Deadline.String() }
+	case "created_at":
+		key = func(p db.Project) string { return timeKey(p.CreatedAt) }
+	case "updated_at":
+		key = func(p db.Project) string { return timeKey(p.UpdatedAt) }
+	}
+	sortByKey(projects, filter.Sort.Desc, key, func(p db.Project) uint { return p.ID })
+
+	return paginate(projects, filter.Page), len(projects), nil
+}
+
+// CountProjects реализует ProjectRepository
+func (m *MemoryStore) CountProjects(ctx context.Context) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.projects), nil
+}
+
+// GetProject реализует ProjectRepository
+func (m *MemoryStore) GetProject(ctx context.Context, id uint) (db.Project, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	project, ok := m.activeProject(id)
+	if !ok {
+		return db.Project{}, ErrNotFound
+	}
+	return project, nil
+}
+
+// CreateProject реализует ProjectRepository
+func (m *MemoryStore) CreateProject(ctx context.Context, project *db.Project) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	project.ID = m.nextProjectID
+	project.ArchivedAt = nil
+	project.DeletedAt = nil
+	project.Version = 1
+	project.Timestamps = newTimestamps(ctx)
+	m.nextProjectID++
+	m.projects[project.ID] = *project
+	if project.OwnerID != nil {
+		m.setMember(project.ID, *project.OwnerID, db.RoleOwner)
+	}
+	return m.recordAudit(ctx, db.AuditCreate, "project", project.ID, project.ID, nil, *project)
+}
+
+// UpdateProject реализует ProjectRepository
+func (m *MemoryStore) UpdateProject(ctx context.Context, project *db.Project) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before, ok := m.activeProject(project.ID)
+	if !ok {
+		return ErrNotFound
+	}
+	if err := checkVersion(project.Version, before.Version); err != nil {
+		return err
+	}
+	existing := before
+	existing.Name = project.Name
+	existing.Description = project.Description
+	existing.Deadline = project.Deadline
+	existing.Experience = project.Experience
+	if existing == before {
+		return nil
+	}
+	existing.Version++
+	existing.UpdatedAt, existing.UpdatedBy = changeStamp(ctx)
+	m.projects[project.ID] = existing
+	if err := m.recordAudit(ctx, db.AuditUpdate, "project", project.ID, project.ID, before, existing); err != nil {
+		return err
+	}
+	return m.recordRevision(ctx, "project", project.ID, project.ID, before.Content(), existing.Content())
+}
+
+// DeleteProject реализует ProjectRepository
+func (m *MemoryStore) DeleteProject(ctx context.Context, id uint, version int, policy DeletePolicy) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before, ok := m.activeProject(id)
+	if !ok {
+		return ErrNotFound
+	}
+	if err := checkVersion(version, before.Version); err != nil {
+		return err
+	}
+	project := before
+	project.Version++
+	now, actorID := changeStamp(ctx)
+	project.UpdatedAt, project.UpdatedBy = now, actorID
+
+	switch policy {
+	case DeletePolicyArchive:
+		if project.ArchivedAt != nil {
+			return nil
+		}
+		project.ArchivedAt = &now
+		m.projects[id] = project
+		return m.recordAudit(ctx, db.AuditArchive, "project", id, id, before, project)
+
+	case DeletePolicyRestrict:
+		blocking := []BlockingVacancy{}
+		for _, v := range m.vacancies {
+			if v.ProjectID == id && v.DeletedAt == nil {
+				blocking = append(blocking, BlockingVacancy{ID: v.ID, Name: v.Name})
+			}
+		}
+		if len(blocking) > 0 {
+			sort.Slice(blocking, func(i, j int) bool { return blocking[i].ID < blocking[j].ID })
+			return &ProjectHasVacanciesError{Vacancies: blocking}
+		}
+
+	default: // DeletePolicyCascade
+		// Та же отметка, что у проекта, - см. RestoreProject
+		for vid, v := range m.vacancies {
+			if v.ProjectID == id && v.DeletedAt == nil {
+				if err := m.recordAudit(ctx, db.AuditDelete, "vacancy", vid, id, v, nil); err != nil {
+					return err
+				}
+				v.DeletedAt = &now
+				v.Version++
+				v.UpdatedAt, v.UpdatedBy = now, actorID
+				m.vacancies[vid] = v
+			}
+		}
+	}
+
+	project.DeletedAt = &now
+	m.projects[id] = project
+	return m.recordAudit(ctx, db.AuditDelete, "project", id, id, before, nil)
+}
+
+// vacancySortKey возвращает ключ сортировки по собственному полю вакансии;
+// для id и полей проекта ключ пустой
+func vacancySortKey(field string) func(db.Vacancy) string {
+	switch field {
+	case "name":
+		return func(v db.Vacancy) string { return v.Name }
+	case "field":
+		return func(v db.Vacancy) string { return v.Field }
+	case "country":
+		return func(v db.Vacancy) string { return v.Country }
+	case "created_at":
+		return func(v db.Vacancy) string { return timeKey(v.CreatedAt) }
+	case "updated_at":
+		return func(v db.Vacancy) string { return timeKey(v.UpdatedAt) }
+	}
+	return func(db.Vacancy) string { return "" }
+}
+
+// ListProjectVacancies реализует VacancyRepository
+func (m *MemoryStore) ListProjectVacancies(ctx context.Context, projectID uint, statuses []db.VacancyStatus, order Sort) ([]db.Vacancy, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	vacancies := []db.Vacancy{}
+	for _, v := range m.vacancies {
+		if v.ProjectID == projectID && v.DeletedAt == nil && hasStatus(statuses, v.Status) {
+			vacancies = append(vacancies, v)
+		}
+	}
+	sortByKey(vacancies, order.Desc, vacancySortKey(order.Field), func(v db.Vacancy) uint { return v.ID })
+	return vacancies, nil
+}
+
+// SearchVacancies реализует VacancyRepository
+func (m *MemoryStore) SearchVacancies(ctx context.Context, filter VacancyFilter) ([]db.VacancyWithProject, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	vacancies := []db.VacancyWithProject{}
+	for _, v := range m.vacancies {
+		project := m.projects[v.ProjectID]
+		switch {
+		case v.DeletedAt != nil,
+			project.ArchivedAt != nil,
+			project.DeletedAt != nil,
+			filter.ProjectID != 0 && v.ProjectID != filter.ProjectID,
+			filter.Field != "" && !strings.EqualFold(v.Field, filter.Field),
+			filter.Country != "" && !strings.EqualFold(v.Country, filter.Country),
+			filter.Experience != "" && v.Experience != filter.Experience,
+			!hasStatus(filter.Statuses, v.Status):
+			continue
+		}
+		vacancies = append(vacancies, db.VacancyWithProject{
+			Vacancy:         v,
+			ProjectName:     project.Name,
+			ProjectDeadline: project.Deadline,
+		})
+	}
+
+	vacancyKey := vacancySortKey(filter.Sort.Field)
+	key := func(v db.VacancyWithProject) string { return vacancyKey(v.Vacancy) }
+	switch filter.Sort.Field {
+	case "project_name":
+		key = func(v db.VacancyWithProject) string { return v.ProjectName }
+	case "deadline":
+		key = func(v db.VacancyWithProject) string { return v.ProjectDeadline.String() }
+	}
+	sortByKey(vacancies, filter.Sort.Desc, key, func(v db.VacancyWithProject) uint { return v.ID })
+
+	return paginate(vacancies, filter.Page), len(vacancies), nil
+}
+
+// GetVacancy реализует VacancyRepository
+func (m *MemoryStore) GetVacancy(ctx context.Context, id uint) (db.Vacancy, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	vacancy, ok := m.activeVacancy(id)
+	if !ok {
+		return db.Vacancy{}, ErrNotFound
+	}
+	return vacancy, nil
+}
+
+// CreateVacancy реализует VacancyRepository
+func (m *MemoryStore) CreateVacancy(ctx context.Context, vacancy *db.Vacancy) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.activeProject(vacancy.ProjectID); !ok {
+		return ErrNotFound
+	}
+	vacancy.ID = m.nextVacancyID
+	vacancy.ClosedAt = nil
+	vacancy.DeletedAt = nil
+	vacancy.Version = 1
+	vacancy.Timestamps = newTimestamps(ctx)
+	if vacancy.Status == "" {
+		vacancy.Status = db.VacancyOpen
+	}
+	m.nextVacancyID++
+	m.vacancies[vacancy.ID] = *vacancy
+	return m.recordAudit(ctx, db.AuditCreate, "vacancy", vacancy.ID, vacancy.ProjectID, nil, *vacancy)
+}
+
+// UpdateVacancy реализует VacancyRepository
+func (m *MemoryStore) UpdateVacancy(ctx context.Context, vacancy *db.Vacancy) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before, ok := m.activeVacancy(vacancy.ID)
+	if !ok {
+		return ErrNotFound
+	}
+	if err := checkVersion(vacancy.Version, before.Version); err != nil {
+		return err
+	}
+	existing := before
+	existing.Name = vacancy.Name
+	existing.Description = vacancy.Description
+	existing.Field = vacancy.Field
+	existing.Country = vacancy.Country
+	existing.Experience = vacancy.Experience
+	if vacancy.Openings > 0 {
+		existing.Openings = vacancy.Openings
+	}
+	if existing == before {
+		return nil
+	}
+	existing.Version++
+	existing.UpdatedAt, existing.UpdatedBy = changeStamp(ctx)
+	m.vacancies[vacancy.ID] = existing
+	if err := m.recordAudit(ctx, db.AuditUpdate, "vacancy", vacancy.ID, existing.ProjectID, before, existing); err != nil {
+		return err
+	}
+	return m.recordRevision(ctx, "vacancy", vacancy.ID, existing.ProjectID, before.Content(), existing.Content())
+}
+
+// DeleteVacancy реализует VacancyRepository
+func (m *MemoryStore) DeleteVacancy(ctx context.Context, id uint, version int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before, ok := m.activeVacancy(id)
+	if !ok {
+		return ErrNotFound
+	}
+	if err := checkVersion(version, before.Version); err != nil {
+		return err
+	}
+	vacancy := before
+	now, actorID := changeStamp(ctx)
+	vacancy.DeletedAt = &now
+	vacancy.Version++
+	vacancy.UpdatedAt, vacancy.UpdatedBy = now, actorID
+	m.vacancies[id] = vacancy
+	return m.recordAudit(ctx, db.AuditDelete, "vacancy", id, vacancy.ProjectID, before, nil)
+}
+
+// hasStatus проверяет статус по фильтру; пустой фильтр пропускает любой статус
+func hasStatus(statuses []db.VacancyStatus, status db.VacancyStatus) bool {
+	if len(statuses) == 0 {
+		return true
+	}
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangeVacancyStatus реализует VacancyRepository
+func (m *MemoryStore) ChangeVacancyStatus(ctx context.Context, id uint, to db.VacancyStatus, version int, today db.Date) (db.Vacancy, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before, ok := m.activeVacancy(id)
+	if !ok {
+		return db.Vacancy{}, ErrNotFound
+	}
+	if err := checkVersion(version, before.Version); err != nil {
+		return db.Vacancy{}, err
+	}
+	vacancy := before
+	if !vacancy.Status.CanBecome(to) {
+		return db.Vacancy{}, &InvalidVacancyTransitionError{From: vacancy.Status, To: to}
+	}
+	if to == db.VacancyOpen {
+		if vacancy.Openings == 0 {
+			return db.Vacancy{}, ErrNoOpenings
+		}
+		if m.projects[vacancy.ProjectID].Deadline.Before(today.Time) {
+			return db.Vacancy{}, ErrDeadlinePassed
+		}
+	}
+
+	now, actorID := changeStamp(ctx)
+	vacancy.Status = to
+	vacancy.ClosedAt = nil
+	if to == db.VacancyClosed {
+		vacancy.ClosedAt = &now
+	}
+	vacancy.Version++
+	vacancy.UpdatedAt, vacancy.UpdatedBy = now, actorID
+	m.vacancies[id] = vacancy
+	return vacancy, m.recordAudit(ctx, db.AuditUpdate, "vacancy", id, vacancy.ProjectID, before, vacancy)
+}
+
+// ExpireVacancies реализует VacancyRepository
+func (m *MemoryStore) ExpireVacancies(ctx context.Context, today db.Date) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Как и в SQLStore, изменения записываются в журнал без автора
+	ctx = WithActor(ctx, Actor{})
+	expired := 0
+	now := time.Now().UTC()
+	for id, v := range m.vacancies {
+		project := m.projects[v.ProjectID]
+		if (v.Status == db.VacancyOpen || v.Status == db.VacancyPaused) && v.DeletedAt == nil && project.DeletedAt == nil && project.Deadline.Before(today.Time) {
+			before := v
+			v.Status = db.VacancyExpired
+			v.Version++
+			v.UpdatedAt, v.UpdatedBy = now, nil
+			m.vacancies[id] = v
+			if err := m.recordAudit(ctx, db.AuditUpdate, "vacancy", id, v.ProjectID, before, v); err != nil {
+				return expired, err
+			}
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+// Search реализует SearchRepository простым поиском подстрок без учета регистра.
+// Ранжирования нет: сначала проекты, затем вакансии, каждые по id.
+func (m *MemoryStore) Search(ctx context.Context, query SearchQuery) ([]db.SearchResult, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	terms := strings.Fields(strings.ToLower(query.Text))
+	matches := func(texts ...string) bool {
+		haystack := strings.ToLower(strings.Join(texts, " "))
+		for _, term := range terms {
+			if !strings.Contains(haystack, term) {
+				return false
+			}
+		}
+		return len(terms) > 0
+	}
+
+	results := []db.SearchResult{}
+	if query.Type == "" || query.Type == "project" {
+		for _, p := range m.projects {
+			if p.ArchivedAt == nil && p.DeletedAt == nil && matches(p.Name, p.Description) {
+				results = append(results, db.SearchResult{Type: "project", ID: p.ID, ProjectID: p.ID, Name: p.Name, Snippet: p.Description})
+			}
+		}
+	}
+	if query.Type == "" || query.Type == "vacancy" {
+		for _, v := range m.vacancies {
+			project := m.projects[v.ProjectID]
+			if project.ArchivedAt == nil && project.DeletedAt == nil && v.DeletedAt == nil && matches(v.Name, v.Description) {
+				results = append(results, db.SearchResult{Type: "vacancy", ID: v.ID, ProjectID: v.ProjectID, Name: v.Name, Snippet: v.Description})
+			}
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Type != results[j].Type {
+			return results[i].Type == "project"
+		}
+		return results[i].ID < results[j].ID
+	})
+
+	return paginate(results, query.Page), len(results), nil
+}
+
+// Проверяем на этапе компиляции, что MemoryStore реализует все интерфейсы
+var (
+	_ ProjectRepository = (*MemoryStore)(nil)
+	_ VacancyRepository = (*MemoryStore)(nil)
+	_ SearchRepository  = (*MemoryStore)(nil)
+)
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// memoryApplications - отклики и их история в MemoryStore
+type memoryApplications struct {
+	applications      map[uint]db.Application
+	applicationEvents map[uint][]db.ApplicationEvent // ключ - id отклика
+	nextApplicationID uint
+	nextEventID       uint
+}
+
+func newMemoryApplications() memoryApplications {
+	return memoryApplications{
+		applications:      make(map[uint]db.Application),
+		applicationEvents: make(map[uint][]db.ApplicationEvent),
+		nextApplicationID: 1,
+		nextEventID:       1,
+	}
+}
+
+// deleteVacancyApplications удаляет отклики на вакансию; вызывается под m.mu
+func (m *MemoryStore) deleteVacancyApplications(vacancyID uint) {
+	for id, a := range m.applications {
+		if a.VacancyID == vacancyID {
+			delete(m.applications, id)
+			delete(m.applicationEvents, id)
+		}
+	}
+}
+
+// addApplicationEvent дописывает событие в историю; вызывается под m.mu
+func (m *MemoryStore) addApplicationEvent(event *db.ApplicationEvent) {
+	event.ID = m.nextEventID
+	m.nextEventID++
+	m.applicationEvents[event.ApplicationID] = append(m.applicationEvents[event.ApplicationID], *event)
+}
+
+// withVacancy подставляет в отклик проект и название вакансии; вызывается под m.mu
+func (m *MemoryStore) withVacancy(application db.Application) db.Application {
+	vacancy := m.vacancies[application.VacancyID]
+	application.ProjectID = vacancy.ProjectID
+	application.VacancyName = vacancy.Name
+	return application
+}
+
+// CreateApplication реализует ApplicationRepository
+func (m *MemoryStore) CreateApplication(ctx context.Context, application *db.Application) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	vacancy, ok := m.activeVacancy(application.VacancyID)
+	if !ok {
+		return ErrNotFound
+	}
+	if vacancy.Status != db.VacancyOpen {
+		return ErrVacancyNotOpen
+	}
+	for _, existing := range m.applications {
+		if existing.VacancyID == application.VacancyID && existing.Email == application.Email {
+			return ErrAlreadyExists
+		}
+	}
+
+	now := time.Now().UTC()
+	application.ID = m.nextApplicationID
+	m.nextApplicationID++
+	application.Status = db.ApplicationSubmitted
+	application.CreatedAt, application.UpdatedAt = now, now
+	*application = m.withVacancy(*application)
+	m.applications[application.ID] = *application
+
+	m.addApplicationEvent(&db.ApplicationEvent{ApplicationID: application.ID, ToStatus: application.Status, CreatedAt: now})
+	return nil
+}
+
+// GetApplication реализует ApplicationRepository
+func (m *MemoryStore) GetApplication(ctx context.Context, id uint) (db.Application, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	application, ok := m.applications[id]
+	if !ok {
+		return db.Application{}, ErrNotFound
+	}
+	return m.withVacancy(application), nil
+}
+
+// ListApplications реализует ApplicationRepository
+func (m *MemoryStore) ListApplications(ctx context.Context, filter ApplicationFilter) ([]db.Application, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	applications := []db.Application{}
+	for _, a := range m.applications {
+		a = m.withVacancy(a)
+		switch {
+		case filter.ProjectID != 0 && a.ProjectID != filter.ProjectID,
+			filter.VacancyID != 0 && a.VacancyID != filter.VacancyID,
+			filter.Status != "" && a.Status != filter.Status:
+			continue
+		}
+		applications = append(applications, a)
+	}
+	sort.Slice(applications, func(i, j int) bool {
+		if !applications[i].CreatedAt.Equal(applications[j].CreatedAt) {
+			return applications[i].CreatedAt.After(applications[j].CreatedAt)
+		}
+		return applications[i].ID > applications[j].ID
+	})
+
+	return paginate(applications, filter.Page), len(applications), nil
+}
+
+// ChangeApplicationStatus реализует ApplicationRepository
+func (m *MemoryStore) ChangeApplicationStatus(ctx context.Context, id uint, event *db.ApplicationEvent) (db.Application, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	application, ok := m.applications[id]
+	if !ok {
+		return db.Application{}, ErrNotFound
+	}
+	if !application.Status.CanBecome(event.ToStatus) {
+		return db.Application{}, &InvalidTransitionError{From: application.Status, To: event.ToStatus}
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now().UTC()
+	}
+
+	event.ApplicationID = id
+	event.FromStatus = application.Status
+	m.addApplicationEvent(event)
+
+	application.Status = event.ToStatus
+	application.UpdatedAt = event.CreatedAt
+	m.applications[id] = application
+	return m.withVacancy(application), nil
+}
+
+// ListApplicationEvents реализует ApplicationRepository
+func (m *MemoryStore) ListApplicationEvents(ctx context.Context, applicationID uint) ([]db.ApplicationEvent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]db.ApplicationEvent{}, m.applicationEvents[applicationID]...), nil
+}
+
+var _ ApplicationRepository = (*MemoryStore)(nil)
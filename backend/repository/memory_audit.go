@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// canEdit повторяет условие editableBy из SQLStore; вызывается под m.mu
+func (m *MemoryStore) canEdit(projectID, userID uint) bool {
+	role := m.members[memberKey{projectID, userID}].Role
+	return role == db.RoleOwner || role == db.RoleManager
+}
+
+// recordAudit дописывает событие в журнал аудита; вызывается под m.mu
+func (m *MemoryStore) recordAudit(ctx context.Context, action db.AuditAction, entityType string, entityID, projectID uint, before, after any) error {
+	event, changed, err := newAuditEvent(ctx, action, entityType, entityID, projectID, before, after)
+	if err != nil || !changed {
+		return err
+	}
+	event.ID = m.nextAuditID
+	m.nextAuditID++
+	m.audit = append(m.audit, event)
+	return nil
+}
+
+// ListAuditEvents реализует AuditRepository
+func (m *MemoryStore) ListAuditEvents(ctx context.Context, filter AuditFilter) ([]db.AuditEvent, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// События дописываются по порядку, поэтому идем с конца - новые первыми
+	events := []db.AuditEvent{}
+	for i := len(m.audit) - 1; i >= 0; i-- {
+		e := m.audit[i]
+		switch {
+		case !m.canEdit(e.ProjectID, filter.UserID),
+			filter.ActorID != 0 && (e.ActorID == nil || *e.ActorID != filter.ActorID),
+			filter.Action != "" && e.Action != filter.Action,
+			filter.EntityType != "" && e.EntityType != filter.EntityType,
+			filter.EntityID != 0 && e.EntityID != filter.EntityID,
+			filter.ProjectID != 0 && e.ProjectID != filter.ProjectID,
+			!filter.Since.IsZero() && e.CreatedAt.Before(filter.Since),
+			!filter.Until.IsZero() && !e.CreatedAt.Before(filter.Until):
+			continue
+		}
+		if e.ActorID != nil {
+			e.ActorName = m.users[*e.ActorID].Name
+		}
+		events = append(events, e)
+	}
+	return paginate(events, filter.Page), len(events), nil
+}
+
+var _ AuditRepository = (*MemoryStore)(nil)
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// memberKey - ключ участника в MemoryStore
+type memberKey struct {
+	projectID uint
+	userID    uint
+}
+
+// setMember добавляет участника или меняет его роль; вызывается под m.mu
+func (m *MemoryStore) setMember(projectID, userID uint, role db.ProjectRole) {
+	key := memberKey{projectID, userID}
+	member, ok := m.members[key]
+	if !ok {
+		member = db.ProjectMember{ProjectID: projectID, UserID: userID, CreatedAt: time.Now().UTC()}
+	}
+	member.Role = role
+	m.members[key] = member
+}
+
+// GetProjectAccess реализует MemberRepository
+func (m *MemoryStore) GetProjectAccess(ctx context.Context, projectID, userID uint) (ProjectAccess, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.projects[projectID]; !ok {
+		return ProjectAccess{}, ErrNotFound
+	}
+	return ProjectAccess{Role: m.members[memberKey{projectID, userID}].Role}, nil
+}
+
+// ListProjectMembers реализует MemberRepository
+func (m *MemoryStore) ListProjectMembers(ctx context.Context, projectID uint) ([]db.ProjectMember, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rank := map[db.ProjectRole]int{db.RoleOwner: 0, db.RoleManager: 1, db.RoleViewer: 2}
+	members := []db.ProjectMember{}
+	for key, member := range m.members {
+		if key.projectID == projectID {
+			user := m.users[key.userID]
+			member.Email, member.Name = user.Email, user.Name
+			members = append(members, member)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if rank[members[i].Role] != rank[members[j].Role] {
+			return rank[members[i].Role] < rank[members[j].Role]
+		}
+		return members[i].UserID < members[j].UserID
+	})
+	return members, nil
+}
+
+// SetProjectMember реализует MemberRepository
+func (m *MemoryStore) SetProjectMember(ctx context.Context, projectID, userID uint, role db.ProjectRole) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.projects[projectID]; !ok {
+		return ErrNotFound
+	}
+	if _, ok := m.users[userID]; !ok {
+		return ErrNotFound
+	}
+	m.setMember(projectID, userID, role)
+	return nil
+}
+
+// RemoveProjectMember реализует MemberRepository
+func (m *MemoryStore) RemoveProjectMember(ctx context.Context, projectID, userID uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := memberKey{projectID, userID}
+	if _, ok := m.members[key]; !ok {
+		return ErrNotFound
+	}
+	delete(m.members, key)
+	return nil
+}
+
+var _ MemberRepository = (*MemoryStore)(nil)
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// recordRevision сохраняет ревизии содержимого записи (см. nextRevisions); вызывается под m.mu
+func (m *MemoryStore) recordRevision(ctx context.Context, entityType string, entityID, projectID uint, before, after any) error {
+	last := 0
+	for _, r := range m.revisions {
+		if r.EntityType == entityType && r.EntityID == entityID && r.Revision > last {
+			last = r.Revision
+		}
+	}
+	revisions, err := nextRevisions(ctx, entityType, entityID, projectID, last, before, after)
+	if err != nil {
+		return err
+	}
+	for _, r := range revisions {
+		r.ID = m.nextRevisionID
+		m.nextRevisionID++
+		m.revisions = append(m.revisions, r)
+	}
+	return nil
+}
+
+// deleteRevisions удаляет ревизии, для которых match возвращает true; вызывается под m.mu
+func (m *MemoryStore) deleteRevisions(match func(db.Revision) bool) {
+	kept := m.revisions[:0]
+	for _, r := range m.revisions {
+		if !match(r) {
+			kept = append(kept, r)
+		}
+	}
+	m.revisions = kept
+}
+
+// entityRevisions - ревизии записи от новых к старым; вызывается под m.mu
+func (m *MemoryStore) entityRevisions(entityType string, entityID uint) []db.Revision {
+	// Ревизии дописываются по порядку, поэтому идем с конца
+	revisions := []db.Revision{}
+	for i := len(m.revisions) - 1; i >= 0; i-- {
+		r := m.revisions[i]
+		if r.EntityType == entityType && r.EntityID == entityID {
+			if r.AuthorID != nil {
+				r.AuthorName = m.users[*r.AuthorID].Name
+			}
+			revisions = append(revisions, r)
+		}
+	}
+	return revisions
+}
+
+// ListRevisions реализует RevisionRepository
+func (m *MemoryStore) ListRevisions(ctx context.Context, entityType string, entityID uint, page Page) ([]db.Revision, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	revisions := m.entityRevisions(entityType, entityID)
+	if err := setRevisionChanges(revisions); err != nil {
+		return nil, 0, err
+	}
+	return paginate(revisions, page), len(revisions), nil
+}
+
+// GetRevision реализует RevisionRepository
+func (m *MemoryStore) GetRevision(ctx context.Context, entityType string, entityID uint, revision int) (db.Revision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, r := range m.entityRevisions(entityType, entityID) {
+		if r.Revision == revision {
+			r.Changes = []db.FieldChange{}
+			return r, nil
+		}
+	}
+	return db.Revision{}, ErrNotFound
+}
+
+var _ RevisionRepository = (*MemoryStore)(nil)
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// detachVacancyTeam отвязывает команду от удаленной вакансии (как ON DELETE SET NULL);
+// вызывается под m.mu
+func (m *MemoryStore) detachVacancyTeam(vacancyID uint) {
+	for id, member := range m.team {
+		if member.VacancyID != nil && *member.VacancyID == vacancyID {
+			member.VacancyID = nil
+			m.team[id] = member
+		}
+	}
+}
+
+// ListTeamMembers реализует TeamRepository
+func (m *MemoryStore) ListTeamMembers(ctx context.Context, projectID uint) ([]db.TeamMember, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	members := []db.TeamMember{}
+	for _, member := range m.team {
+		if member.ProjectID == projectID {
+			members = append(members, member)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].ID < members[j].ID })
+	return members, nil
+}
+
+// AddTeamMember реализует TeamRepository
+func (m *MemoryStore) AddTeamMember(ctx context.Context, member *db.TeamMember) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.activeProject(member.ProjectID); !ok {
+		return ErrNotFound
+	}
+	if member.CreatedAt.IsZero() {
+		member.CreatedAt = time.Now().UTC()
+	}
+
+	if member.VacancyID != nil {
+		vacancy, ok := m.activeVacancy(*member.VacancyID)
+		if !ok || vacancy.ProjectID != member.ProjectID {
+			return ErrNotFound
+		}
+		if err := checkHire(vacancy); err != nil {
+			return err
+		}
+		before := vacancy
+		vacancy.Openings--
+		if vacancy.Openings == 0 {
+			closedAt := member.CreatedAt
+			vacancy.Status = db.VacancyClosed
+			vacancy.ClosedAt = &closedAt
+		}
+		vacancy.Version++
+		vacancy.UpdatedAt, vacancy.UpdatedBy = member.CreatedAt, actorFrom(ctx).UserID
+		m.vacancies[vacancy.ID] = vacancy
+		if err := m.recordAudit(ctx, db.AuditUpdate, "vacancy", vacancy.ID, vacancy.ProjectID, before, vacancy); err != nil {
+			return err
+		}
+	}
+
+	member.ID = m.nextTeamMemberID
+	m.nextTeamMemberID++
+	m.team[member.ID] = *member
+	return nil
+}
+
+// RemoveTeamMember реализует TeamRepository.
+// Как и в SQLStore, снова открывает только вакансию, закрытую последним наймом.
+func (m *MemoryStore) RemoveTeamMember(ctx context.Context, projectID, memberID uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	member, ok := m.team[memberID]
+	if !ok || member.ProjectID != projectID {
+		return ErrNotFound
+	}
+	delete(m.team, memberID)
+
+	if member.VacancyID != nil {
+		if vacancy, ok := m.vacancies[*member.VacancyID]; ok {
+			before := vacancy
+			// вакансия закрыта последним наймом, а не вручную
+			if vacancy.Status == db.VacancyClosed && vacancy.Openings == 0 {
+				vacancy.Status = db.VacancyOpen
+				vacancy.ClosedAt = nil
+			}
+			vacancy.Openings++
+			vacancy.Version++
+			vacancy.UpdatedAt, vacancy.UpdatedBy = changeStamp(ctx)
+			m.vacancies[vacancy.ID] = vacancy
+			return m.recordAudit(ctx, db.AuditUpdate, "vacancy", vacancy.ID, vacancy.ProjectID, before, vacancy)
+		}
+	}
+	return nil
+}
+
+// GetProjectStaffing реализует TeamRepository
+func (m *MemoryStore) GetProjectStaffing(ctx context.Context, projectID uint) (db.ProjectStaffing, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var staffing db.ProjectStaffing
+	for _, member := range m.team {
+		if member.ProjectID == projectID {
+			staffing.Headcount++
+		}
+	}
+	for _, v := range m.vacancies {
+		if v.ProjectID == projectID && v.Status == db.VacancyOpen && v.DeletedAt == nil {
+			staffing.OpenPositions += v.Openings
+		}
+	}
+	return staffing, nil
+}
+
+var _ TeamRepository = (*MemoryStore)(nil)
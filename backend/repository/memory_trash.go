@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// ListTrash реализует TrashRepository
+func (m *MemoryStore) ListTrash(ctx context.Context, filter TrashFilter) ([]db.TrashItem, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	items := []db.TrashItem{}
+	if filter.Type == "" || filter.Type == "project" {
+		for _, p := range m.projects {
+			if p.DeletedAt != nil && m.canEdit(p.ID, filter.UserID) {
+				items = append(items, db.TrashItem{Type: "project", ID: p.ID, ProjectID: p.ID, Name: p.Name, DeletedAt: *p.DeletedAt})
+			}
+		}
+	}
+	if filter.Type == "" || filter.Type == "vacancy" {
+		for _, v := range m.vacancies {
+			project := m.projects[v.ProjectID]
+			withProject := project.DeletedAt != nil && v.DeletedAt != nil && project.DeletedAt.Equal(*v.DeletedAt)
+			if v.DeletedAt != nil && !withProject && m.canEdit(v.ProjectID, filter.UserID) {
+				items = append(items, db.TrashItem{Type: "vacancy", ID: v.ID, ProjectID: v.ProjectID, Name: v.Name, DeletedAt: *v.DeletedAt})
+			}
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].DeletedAt.Equal(items[j].DeletedAt) {
+			return items[i].DeletedAt.After(items[j].DeletedAt)
+		}
+		if items[i].Type != items[j].Type {
+			return items[i].Type < items[j].Type
+		}
+		return items[i].ID < items[j].ID
+	})
+
+	return paginate(items, filter.Page), len(items), nil
+}
+
+// GetTrashItem реализует TrashRepository
+func (m *MemoryStore) GetTrashItem(ctx context.Context, itemType string, id uint) (db.TrashItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch itemType {
+	case "project":
+		if p, ok := m.projects[id]; ok && p.DeletedAt != nil {
+			return db.TrashItem{Type: "project", ID: p.ID, ProjectID: p.ID, Name: p.Name, DeletedAt: *p.DeletedAt}, nil
+		}
+	case "vacancy":
+		if v, ok := m.vacancies[id]; ok && v.DeletedAt != nil {
+			return db.TrashItem{Type: "vacancy", ID: v.ID, ProjectID: v.ProjectID, Name: v.Name, DeletedAt: *v.DeletedAt}, nil
+		}
+	}
+	return db.TrashItem{}, ErrNotFound
+}
+
+// RestoreProject реализует TrashRepository
+func (m *MemoryStore) RestoreProject(ctx context.Context, id uint) (db.Project, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before, ok := m.projects[id]
+	if !ok || before.DeletedAt == nil {
+		return db.Project{}, ErrNotFound
+	}
+	now, actorID := changeStamp(ctx)
+	for vid, v := range m.vacancies {
+		if v.ProjectID == id && v.DeletedAt != nil && v.DeletedAt.Equal(*before.DeletedAt) {
+			restored := v
+			restored.DeletedAt = nil
+			restored.Version++
+			restored.UpdatedAt, restored.UpdatedBy = now, actorID
+			m.vacancies[vid] = restored
+			if err := m.recordAudit(ctx, db.AuditRestore, "vacancy", vid, id, v, restored); err != nil {
+				return db.Project{}, err
+			}
+		}
+	}
+	project := before
+	project.DeletedAt = nil
+	project.Version++
+	project.UpdatedAt, project.UpdatedBy = now, actorID
+	m.projects[id] = project
+	return project, m.recordAudit(ctx, db.AuditRestore, "project", id, id, before, project)
+}
+
+// RestoreVacancy реализует TrashRepository
+func (m *MemoryStore) RestoreVacancy(ctx context.Context, id uint) (db.Vacancy, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before, ok := m.vacancies[id]
+	if !ok || before.DeletedAt == nil {
+		return db.Vacancy{}, ErrNotFound
+	}
+	if m.projects[before.ProjectID].DeletedAt != nil {
+		return db.Vacancy{}, ErrProjectDeleted
+	}
+	vacancy := before
+	vacancy.DeletedAt = nil
+	vacancy.Version++
+	vacancy.UpdatedAt, vacancy.UpdatedBy = changeStamp(ctx)
+	m.vacancies[id] = vacancy
+	return vacancy, m.recordAudit(ctx, db.AuditRestore, "vacancy", id, vacancy.ProjectID, before, vacancy)
+}
+
+// PurgeTrash реализует TrashRepository
+func (m *MemoryStore) PurgeTrash(ctx context.Context, before time.Time) (PurgeResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expired := func(deletedAt *time.Time) bool {
+		return deletedAt != nil && deletedAt.Before(before)
+	}
+
+	var purged PurgeResult
+	for vid, v := range m.vacancies {
+		if expired(v.DeletedAt) || expired(m.projects[v.ProjectID].DeletedAt) {
+			delete(m.vacancies, vid)
+			m.deleteRevisions(func(r db.Revision) bool { return r.EntityType == "vacancy" && r.EntityID == vid })
+			m.deleteVacancyApplications(vid)
+			m.detachVacancyTeam(vid)
+			purged.Vacancies++
+		}
+	}
+	for id, p := range m.projects {
+		if !expired(p.DeletedAt) {
+			continue
+		}
+		for key := range m.members {
+			if key.projectID == id {
+				delete(m.members, key)
+			}
+		}
+		for tid, member := range m.team {
+			if member.ProjectID == id {
+				delete(m.team, tid)
+			}
+		}
+		m.deleteRevisions(func(r db.Revision) bool { return r.ProjectID == id })
+		delete(m.projects, id)
+		purged.Projects++
+	}
+	return purged, nil
+}
+
+var _ TrashRepository = (*MemoryStore)(nil)
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// memoryUsers - пользователи и сессии MemoryStore
+type memoryUsers struct {
+	users         map[uint]db.User
+	sessions      map[string]db.Session // ключ - хеш токена
+	nextUserID    uint
+	nextSessionID uint
+}
+
+func newMemoryUsers() memoryUsers {
+	return memoryUsers{
+		users:         make(map[uint]db.User),
+		sessions:      make(map[string]db.Session),
+		nextUserID:    1,
+		nextSessionID: 1,
+	}
+}
+
+// CreateUser реализует UserRepository
+func (m *MemoryStore) CreateUser(ctx context.Context, user *db.User) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, existing := range m.users {
+		if existing.Email == user.Email {
+			return ErrAlreadyExists
+		}
+	}
+	user.ID = m.nextUserID
+	m.nextUserID++
+	m.users[user.ID] = *user
+	return nil
+}
+
+// GetUser реализует UserRepository
+func (m *MemoryStore) GetUser(ctx context.Context, id uint) (db.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[id]
+	if !ok {
+		return db.User{}, ErrNotFound
+	}
+	return user, nil
+}
+
+// GetUserByEmail реализует UserRepository
+func (m *MemoryStore) GetUserByEmail(ctx context.Context, email string) (db.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, user := range m.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return db.User{}, ErrNotFound
+}
+
+// CreateSession реализует UserRepository
+func (m *MemoryStore) CreateSession(ctx context.Context, session *db.Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[session.UserID]; !ok {
+		return ErrNotFound
+	}
+	session.ID = m.nextSessionID
+	m.nextSessionID++
+	m.sessions[session.TokenHash] = *session
+	return nil
+}
+
+// GetSession реализует UserRepository
+func (m *MemoryStore) GetSession(ctx context.Context, tokenHash string) (db.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[tokenHash]
+	if !ok {
+		return db.Session{}, ErrNotFound
+	}
+	return session, nil
+}
+
+// RotateSession реализует UserRepository
+func (m *MemoryStore) RotateSession(ctx context.Context, oldID uint, next *db.Session, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for hash, session := range m.sessions {
+		if session.ID != oldID {
+			continue
+		}
+		if session.RevokedAt != nil {
+			return ErrNotFound
+		}
+		session.RevokedAt = &now
+		m.sessions[hash] = session
+
+		next.ID = m.nextSessionID
+		m.nextSessionID++
+		m.sessions[next.TokenHash] = *next
+		return nil
+	}
+	return ErrNotFound
+}
+
+// RevokeSessionFamily реализует UserRepository
+func (m *MemoryStore) RevokeSessionFamily(ctx context.Context, familyID string, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for hash, session := range m.sessions {
+		if session.FamilyID == familyID && session.RevokedAt == nil {
+			session.RevokedAt = &now
+			m.sessions[hash] = session
+		}
+	}
+	return nil
+}
+
+var _ UserRepository = (*MemoryStore)(nil)
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+func TestListProjects(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s store) {
+		ctx := context.Background()
+		// ID 1-5: дедлайны через 10, 20, ..., 50 дней, опыт чередуется
+		for i, name := range []string{"Echo", "Delta", "Charlie", "Bravo", "Alpha"} {
+			createProject(t, s, name, func(p *db.Project) {
+				p.Deadline = daysFromToday(10 * (i + 1))
+				if i%2 == 1 {
+					p.Experience = "3+ years"
+				}
+			})
+		}
+		// Проект 4 архивирован, проект 5 удален в корзину
+		if err := s.DeleteProject(ctx, 4, 0, DeletePolicyArchive); err != nil {
+			t.Fatalf("archive: %v", err)
+		}
+		if err := s.DeleteProject(ctx, 5, 0, DeletePolicyCascade); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+
+		tests := []struct {
+			name      string
+			filter    ProjectFilter
+			wantIDs   []uint
+			wantTotal int
+		}{
+			{"all active", ProjectFilter{}, []uint{1, 2, 3}, 3},
+			{"first page", ProjectFilter{Page: Page{Limit: 2}}, []uint{1, 2}, 3},
+			{"second page", ProjectFilter{Page: Page{Limit: 2, Offset: 2}}, []uint{3}, 3},
+			{"past the last page", ProjectFilter{Page: Page{Limit: 2, Offset: 4}}, []uint{}, 3},
+			{"include archived", ProjectFilter{IncludeArchived: true}, []uint{1, 2, 3, 4}, 4},
+			{"experience", ProjectFilter{Experience: "3+ years", IncludeArchived: true}, []uint{2, 4}, 2},
+			{"deadline range is inclusive", ProjectFilter{DeadlineAfter: daysFromToday(20), DeadlineBefore: daysFromToday(30)}, []uint{2, 3}, 2},
+			{"sort by name", ProjectFilter{Sort: Sort{Field: "name"}}, []uint{3, 2, 1}, 3},
+			{"sort by deadline desc", ProjectFilter{Sort: Sort{Field: "deadline", Desc: true}, Page: Page{Limit: 2}}, []uint{3, 2}, 3},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if tt.filter.Page.Limit == 0 {
+					tt.filter.Page.Limit = 100
+				}
+				projects, total, err := s.ListProjects(ctx, tt.filter)
+				if err != nil {
+					t.Fatalf("ListProjects: %v", err)
+				}
+				if got := projectIDs(projects); !slices.Equal(got, tt.wantIDs) || total != tt.wantTotal {
+					t.Errorf("ListProjects = %v (total %d), want %v (total %d)", got, total, tt.wantIDs, tt.wantTotal)
+				}
+			})
+		}
+
+		count, err := s.CountProjects(ctx)
+		if err != nil || count != 5 {
+			t.Errorf("CountProjects = %d, %v; want 5 including archived and deleted", count, err)
+		}
+	})
+}
+
+func TestUpdateProjectVersion(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s store) {
+		ctx := context.Background()
+		project := createProject(t, s, "Landing", nil)
+		if project.Version != 1 {
+			t.Fatalf("new project version = %d, want 1", project.Version)
+		}
+
+		tests := []struct {
+			name        string
+			version     int
+			newName     string
+			wantErr     error
+			wantVersion int
+		}{
+			{"any version", 0, "Landing v2", nil, 2},
+			{"current version", 2, "Landing v3", nil, 3},
+			{"stale version", 2, "Landing v4", ErrVersionMismatch, 3},
+			{"no changes keep the version", 3, "Landing v3", nil, 3},
+		}
+		for _, tt := range tests {
+			update := project
+			update.Name = tt.newName
+			update.Version = tt.version
+			err := s.UpdateProject(ctx, &update)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("%s: UpdateProject error = %v, want %v", tt.name, err, tt.wantErr)
+			}
+			saved, err := s.GetProject(ctx, project.ID)
+			if err != nil {
+				t.Fatalf("GetProject: %v", err)
+			}
+			if saved.Version != tt.wantVersion {
+				t.Errorf("%s: version = %d, want %d", tt.name, saved.Version, tt.wantVersion)
+			}
+		}
+	})
+}
@@ -0,0 +1,353 @@
+// Package repository отделяет работу с хранилищем от HTTP-обработчиков.
+// Обработчики зависят только от интерфейсов ниже; SQLStore хранит данные в базе,
+// MemoryStore - в памяти процесса (для тестов и локальных экспериментов).
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// ErrNotFound возвращается, когда запрошенной записи нет в хранилище
+var ErrNotFound = errors.New("not found")
+
+// ErrNoOpenings возвращается, когда человека принимают на вакансию, в которой не осталось мест
+var ErrNoOpenings = errors.New("vacancy has no openings")
+
+// ErrVacancyNotOpen возвращается при отклике или найме на вакансию не в статусе open
+var ErrVacancyNotOpen = errors.New("vacancy is not open")
+
+// ErrDeadlinePassed возвращается при попытке открыть вакансию проекта, дедлайн которого прошел
+var ErrDeadlinePassed = errors.New("project deadline has passed")
+
+// ErrProjectDeleted возвращается при восстановлении вакансии, проект которой тоже в корзине
+var ErrProjectDeleted = errors.New("project is deleted")
+
+// ErrAlreadyExists возвращается при нарушении уникальности (например, email уже занят)
+var ErrAlreadyExists = errors.New("already exists")
+
+// ErrVersionMismatch возвращается, когда запись изменилась после того, как клиент
+// получил ее версию (ожидаемая версия из If-Match не совпадает с текущей)
+var ErrVersionMismatch = errors.New("version mismatch")
+
+// checkVersion сравнивает ожидаемую версию записи с текущей; 0 - подходит любая
+func checkVersion(expected, current int) error {
+	if expected > 0 && expected != current {
+		return ErrVersionMismatch
+	}
+	return nil
+}
+
+// DeletePolicy определяет, что происходит с проектом и его вакансиями при удалении
+type DeletePolicy string
+
+const (
+	DeletePolicyCascade  DeletePolicy = "cascade"  // удалить проект в корзину вместе с вакансиями
+	DeletePolicyRestrict DeletePolicy = "restrict" // запретить удаление, пока у проекта есть вакансии
+	DeletePolicyArchive  DeletePolicy = "archive"  // не удалять, а пометить проект архивным
+)
+
+// ParseDeletePolicy проверяет строковое значение политики удаления
+func ParseDeletePolicy(value string) (DeletePolicy, error) {
+	switch policy := DeletePolicy(value); policy {
+	case DeletePolicyCascade, DeletePolicyRestrict, DeletePolicyArchive:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown project delete policy %q (expected cascade, restrict or archive)", value)
+}
+
+// BlockingVacancy - вакансия, которая мешает удалить проект при политике restrict
+type BlockingVacancy struct {
+	ID   uint   `db:"id" json:"id"`
+	Name string `db:"name" json:"name"`
+}
+
+// ProjectHasVacanciesError возвращается DeleteProject при политике restrict
+type ProjectHasVacanciesError struct {
+	Vacancies []BlockingVacancy
+}
+
+func (e *ProjectHasVacanciesError) Error() string {
+	return fmt.Sprintf("project has %d vacancies", len(e.Vacancies))
+}
+
+// Page - параметры постраничной выборки
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+// Sort - поле и направление сортировки.
+// Допустимые значения Field перечислены в ProjectSortFields, VacancySortFields
+// и ProjectVacancySortFields.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Поля, по которым разрешена сортировка списков
+var (
+	ProjectSortFields = []string{"id", "name", "deadline", "created_at", "updated_at"}
+	VacancySortFields = []string{"id", "name", "field", "country", "project_name", "deadline", "created_at", "updated_at"}
+	// ProjectVacancySortFields - для вакансий одного проекта поля проекта не нужны
+	ProjectVacancySortFields = []string{"id", "name", "field", "country", "created_at", "updated_at"}
+)
+
+// ProjectFilter - условия выборки для ListProjects
+type ProjectFilter struct {
+	Experience      string
+	DeadlineAfter   db.Date // включительно
+	DeadlineBefore  db.Date // включительно
+	IncludeArchived bool
+	Sort            Sort
+	Page            Page
+}
+
+// VacancyFilter - условия выборки для SearchVacancies.
+// Вакансии архивных проектов в выборку не попадают.
+type VacancyFilter struct {
+	ProjectID  uint
+	Field      string // без учета регистра
+	Country    string // без учета регистра
+	Experience string
+	Statuses   []db.VacancyStatus // пустой список - любые статусы
+	Sort       Sort
+	Page       Page
+}
+
+// SearchQuery - параметры полнотекстового поиска
+type SearchQuery struct {
+	Text string
+	Type string // "", "project" или "vacancy"
+	Page Page
+}
+
+// ProjectRepository - хранилище проектов
+type ProjectRepository interface {
+	// ListProjects возвращает страницу проектов и общее количество под фильтром
+	ListProjects(ctx context.Context, filter ProjectFilter) ([]db.Project, int, error)
+	// CountProjects считает все проекты, в том числе архивные и удаленные в корзину
+	CountProjects(ctx context.Context) (int, error)
+	GetProject(ctx context.Context, id uint) (db.Project, error)
+	// CreateProject сохраняет проект и заполняет его ID.
+	// Если задан project.OwnerID, владелец сразу становится участником с ролью owner.
+	CreateProject(ctx context.Context, project *db.Project) error
+	// UpdateProject перезаписывает поля проекта с project.ID.
+	// project.Version > 0 - ожидаемая текущая версия: если проект уже изменился, возвращает
+	// ErrVersionMismatch. Если поля не изменились, версия остается прежней.
+	UpdateProject(ctx context.Context, project *db.Project) error
+	// DeleteProject удаляет проект в корзину (или архивирует) согласно политике.
+	// При политике restrict и наличии вакансий возвращает *ProjectHasVacanciesError.
+	// version > 0 - ожидаемая текущая версия проекта, как в UpdateProject.
+	// Удаленные проекты и вакансии не видны остальным методам, кроме TrashRepository
+	// и MemberRepository.GetProjectAccess.
+	DeleteProject(ctx context.Context, id uint, version int, policy DeletePolicy) error
+}
+
+// VacancyRepository - хранилище вакансий
+type VacancyRepository interface {
+	// ListProjectVacancies возвращает вакансии проекта в порядке order; пустой statuses - в любых статусах
+	ListProjectVacancies(ctx context.Context, projectID uint, statuses []db.VacancyStatus, order Sort) ([]db.Vacancy, error)
+	// SearchVacancies ищет вакансии во всех проектах и возвращает общее количество под фильтром
+	SearchVacancies(ctx context.Context, filter VacancyFilter) ([]db.VacancyWithProject, int, error)
+	GetVacancy(ctx context.Context, id uint) (db.Vacancy, error)
+	// CreateVacancy сохраняет вакансию и заполняет её ID. Пустой Status означает open.
+	// Возвращает ErrNotFound, если проекта vacancy.ProjectID нет.
+	CreateVacancy(ctx context.Context, vacancy *db.Vacancy) error
+	// UpdateVacancy перезаписывает поля вакансии с vacancy.ID (project_id не меняется).
+	// Openings > 0 задает новое число мест, 0 оставляет их как есть.
+	// Статус здесь не меняется, даже у вакансии без мест, - для этого есть ChangeVacancyStatus.
+	// vacancy.Version > 0 - ожидаемая текущая версия, как в UpdateProject.
+	UpdateVacancy(ctx context.Context, vacancy *db.Vacancy) error
+	// DeleteVacancy удаляет вакансию в корзину.
+	// version > 0 - ожидаемая текущая версия, как в UpdateProject.
+	DeleteVacancy(ctx context.Context, id uint, version int) error
+	// ChangeVacancyStatus переводит вакансию в статус to.
+	// Возвращает *InvalidVacancyTransitionError, если переход не разрешен,
+	// ErrNoOpenings при открытии вакансии без мест и ErrDeadlinePassed при
+	// открытии вакансии проекта, дедлайн которого раньше today.
+	// version > 0 - ожидаемая текущая версия, как в UpdateProject.
+	ChangeVacancyStatus(ctx context.Context, id uint, to db.VacancyStatus, version int, today db.Date) (db.Vacancy, error)
+	// ExpireVacancies переводит в expired открытые и приостановленные вакансии
+	// проектов с дедлайном раньше today и возвращает их количество
+	ExpireVacancies(ctx context.Context, today db.Date) (int, error)
+}
+
+// InvalidVacancyTransitionError возвращается ChangeVacancyStatus, когда
+// из текущего статуса вакансии нельзя перейти в запрошенный
+type InvalidVacancyTransitionError struct {
+	From db.VacancyStatus
+	To   db.VacancyStatus
+}
+
+func (e *InvalidVacancyTransitionError) Error() string {
+	return fmt.Sprintf("cannot change vacancy status from %s to %s", e.From, e.To)
+}
+
+// SearchRepository - полнотекстовый поиск по проектам и вакансиям
+type SearchRepository interface {
+	// Search возвращает результаты по убыванию релевантности и общее количество найденного
+	Search(ctx context.Context, query SearchQuery) ([]db.SearchResult, int, error)
+}
+
+// TrashFilter - условия выборки для ListTrash
+type TrashFilter struct {
+	// UserID - пользователь, для которого собирается корзина: видны записи проектов,
+	// где он владелец или менеджер (см. handlers.authorizeProject)
+	UserID uint
+	Type   string // "", "project" или "vacancy"
+	Page   Page
+}
+
+// PurgeResult - сколько записей окончательно удалено из корзины
+type PurgeResult struct {
+	Projects  int
+	Vacancies int
+}
+
+// TrashRepository - корзина: удаленные проекты и вакансии
+type TrashRepository interface {
+	// ListTrash возвращает страницу корзины (недавно удаленные первыми) и общее количество под фильтром
+	ListTrash(ctx context.Context, filter TrashFilter) ([]db.TrashItem, int, error)
+	// GetTrashItem возвращает удаленный проект или вакансию; ErrNotFound, если в корзине такой записи нет
+	GetTrashItem(ctx context.Context, itemType string, id uint) (db.TrashItem, error)
+	// RestoreProject возвращает проект из корзины вместе с вакансиями, удаленными вместе с ним.
+	// ErrNotFound - проекта нет в корзине.
+	RestoreProject(ctx context.Context, id uint) (db.Project, error)
+	// RestoreVacancy возвращает вакансию из корзины.
+	// ErrNotFound - вакансии нет в корзине; ErrProjectDeleted - ее проект тоже в корзине.
+	RestoreVacancy(ctx context.Context, id uint) (db.Vacancy, error)
+	// PurgeTrash окончательно удаляет проекты и вакансии, удаленные раньше before,
+	// вместе с откликами, участниками и командой
+	PurgeTrash(ctx context.Context, before time.Time) (PurgeResult, error)
+}
+
+// AuditFilter - условия выборки для ListAuditEvents. Нулевые значения не ограничивают выборку.
+type AuditFilter struct {
+	// UserID - кто смотрит журнал: видны события проектов, где он владелец или менеджер
+	UserID     uint
+	ActorID    uint
+	Action     db.AuditAction
+	EntityType string // "project" или "vacancy"
+	EntityID   uint
+	ProjectID  uint
+	Since      time.Time // включительно
+	Until      time.Time // не включительно
+	Page       Page
+}
+
+// AuditRepository - журнал аудита изменений проектов и вакансий.
+// Записи добавляют сами ProjectRepository, VacancyRepository и TrashRepository
+// в транзакции изменения; автор берется из контекста (см. WithActor).
+type AuditRepository interface {
+	// ListAuditEvents возвращает страницу журнала (новые первыми) и общее количество под фильтром
+	ListAuditEvents(ctx context.Context, filter AuditFilter) ([]db.AuditEvent, int, error)
+}
+
+// RevisionRepository - ревизии содержимого проектов и вакансий.
+// Ревизии добавляют сами UpdateProject и UpdateVacancy в транзакции изменения;
+// автор берется из контекста (см. WithActor).
+type RevisionRepository interface {
+	// ListRevisions возвращает страницу ревизий записи entityType (новые первыми)
+	// с изменениями относительно предыдущей ревизии и общее количество ревизий
+	ListRevisions(ctx context.Context, entityType string, entityID uint, page Page) ([]db.Revision, int, error)
+	// GetRevision возвращает ревизию записи; ErrNotFound, если такой ревизии нет
+	GetRevision(ctx context.Context, entityType string, entityID uint, revision int) (db.Revision, error)
+}
+
+// ProjectAccess - права пользователя в проекте
+type ProjectAccess struct {
+	// Role - роль пользователя; пустая строка, если он не участник
+	Role db.ProjectRole
+}
+
+// ApplicationFilter - условия выборки для ListApplications.
+// Нулевые ProjectID и VacancyID и пустой Status не ограничивают выборку.
+type ApplicationFilter struct {
+	ProjectID uint
+	VacancyID uint
+	Status    db.ApplicationStatus
+	Page      Page
+}
+
+// InvalidTransitionError возвращается ChangeApplicationStatus, когда
+// из текущего статуса отклика нельзя перейти в запрошенный
+type InvalidTransitionError struct {
+	From db.ApplicationStatus
+	To   db.ApplicationStatus
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("cannot change application status from %s to %s", e.From, e.To)
+}
+
+// ApplicationRepository - отклики на вакансии и история их статусов
+type ApplicationRepository interface {
+	// CreateApplication сохраняет отклик в статусе submitted, заполняет ID и
+	// временные метки и пишет первую запись истории.
+	// ErrNotFound - вакансии нет; ErrVacancyNotOpen - вакансия не принимает отклики;
+	// ErrAlreadyExists - этот email уже откликался на вакансию.
+	CreateApplication(ctx context.Context, application *db.Application) error
+	GetApplication(ctx context.Context, id uint) (db.Application, error)
+	// ListApplications возвращает страницу откликов (новые первыми) и общее количество под фильтром
+	ListApplications(ctx context.Context, filter ApplicationFilter) ([]db.Application, int, error)
+	// ChangeApplicationStatus переводит отклик в event.ToStatus и дописывает event в историю.
+	// FromStatus и ApplicationID события заполняются хранилищем, пустой CreatedAt - текущим временем.
+	// Возвращает *InvalidTransitionError, если переход не разрешен.
+	ChangeApplicationStatus(ctx context.Context, id uint, event *db.ApplicationEvent) (db.Application, error)
+	// ListApplicationEvents возвращает историю статусов отклика, от старых к новым
+	ListApplicationEvents(ctx context.Context, applicationID uint) ([]db.ApplicationEvent, error)
+}
+
+// TeamRepository - команда проекта и учет мест в вакансиях
+type TeamRepository interface {
+	// ListTeamMembers возвращает команду проекта в порядке добавления
+	ListTeamMembers(ctx context.Context, projectID uint) ([]db.TeamMember, error)
+	// AddTeamMember добавляет человека в команду и заполняет ID. Если задан VacancyID,
+	// в той же транзакции уменьшает Openings вакансии и закрывает ее на нуле.
+	// ErrNotFound - нет проекта или вакансии в этом проекте; ErrNoOpenings - мест не осталось;
+	// ErrVacancyNotOpen - вакансия не в статусе open.
+	AddTeamMember(ctx context.Context, member *db.TeamMember) error
+	// RemoveTeamMember убирает человека из команды. Место в его вакансии
+	// освобождается; вакансия, закрытая последним наймом, снова открывается.
+	RemoveTeamMember(ctx context.Context, projectID, memberID uint) error
+	// GetProjectStaffing считает людей в команде и свободные места в открытых вакансиях проекта
+	GetProjectStaffing(ctx context.Context, projectID uint) (db.ProjectStaffing, error)
+}
+
+// MemberRepository - участники проектов и их роли
+type MemberRepository interface {
+	// GetProjectAccess возвращает права пользователя в проекте; ErrNotFound, если проекта нет.
+	// Проекты в корзине учитываются: права нужны, чтобы их восстановить.
+	GetProjectAccess(ctx context.Context, projectID, userID uint) (ProjectAccess, error)
+	ListProjectMembers(ctx context.Context, projectID uint) ([]db.ProjectMember, error)
+	// SetProjectMember добавляет участника или меняет его роль.
+	// Возвращает ErrNotFound, если нет проекта или пользователя.
+	SetProjectMember(ctx context.Context, projectID, userID uint, role db.ProjectRole) error
+	// RemoveProjectMember удаляет участника; ErrNotFound, если его не было
+	RemoveProjectMember(ctx context.Context, projectID, userID uint) error
+}
+
+// UserRepository - хранилище пользователей и их сессий
+type UserRepository interface {
+	// CreateUser сохраняет пользователя и заполняет его ID.
+	// Возвращает ErrAlreadyExists, если email уже занят.
+	CreateUser(ctx context.Context, user *db.User) error
+	GetUser(ctx context.Context, id uint) (db.User, error)
+	// GetUserByEmail ищет пользователя по email (email хранится в нижнем регистре)
+	GetUserByEmail(ctx context.Context, email string) (db.User, error)
+
+	// CreateSession сохраняет сессию (refresh-токен) и заполняет её ID
+	CreateSession(ctx context.Context, session *db.Session) error
+	// GetSession возвращает сессию по хешу токена, в том числе истекшую или отозванную
+	GetSession(ctx context.Context, tokenHash string) (db.Session, error)
+	// RotateSession отзывает сессию oldID и сохраняет next в одной транзакции.
+	// Возвращает ErrNotFound, если oldID уже отозвана (токен предъявлен повторно).
+	RotateSession(ctx context.Context, oldID uint, next *db.Session, now time.Time) error
+	// RevokeSessionFamily отзывает все еще действующие сессии семейства
+	RevokeSessionFamily(ctx context.Context, familyID string, now time.Time) error
+}
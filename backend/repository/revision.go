@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// nextRevisions готовит ревизии, которые нужно добавить после изменения содержимого
+// записи с before на after (db.ProjectContent или db.VacancyContent). last - номер
+// последней сохраненной ревизии записи. Если ревизий еще нет, первой сохраняется
+// исходное состояние before. Если содержимое не изменилось, добавлять нечего.
+func nextRevisions(ctx context.Context, entityType string, entityID, projectID uint, last int, before, after any) ([]db.Revision, error) {
+	if reflect.DeepEqual(before, after) {
+		return nil, nil
+	}
+
+	now := time.Now().UTC()
+	var revisions []db.Revision
+	add := func(content any, authorID *uint) error {
+		encoded, err := json.Marshal(content)
+		if err != nil {
+			return err
+		}
+		last++
+		revisions = append(revisions, db.Revision{
+			EntityType: entityType,
+			EntityID:   entityID,
+			ProjectID:  projectID,
+			Revision:   last,
+			Content:    encoded,
+			AuthorID:   authorID,
+			CreatedAt:  now,
+		})
+		return nil
+	}
+
+	if last == 0 {
+		// Автор исходного состояния неизвестен
+		if err := add(before, nil); err != nil {
+			return nil, err
+		}
+	}
+	if err := add(after, actorFrom(ctx).UserID); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// setRevisionChanges заполняет Changes у ревизий, отсортированных от новых к старым:
+// каждая сравнивается со следующей в списке. У последней в списке Changes остается
+// пустым, поэтому для страницы из n ревизий нужно передать n+1 (если они есть).
+func setRevisionChanges(revisions []db.Revision) error {
+	for i := range revisions {
+		revisions[i].Changes = []db.FieldChange{}
+		if i+1 == len(revisions) {
+			break
+		}
+		changes, err := revisionChanges(revisions[i+1].Content, revisions[i].Content)
+		if err != nil {
+			return err
+		}
+		revisions[i].Changes = changes
+	}
+	return nil
+}
+
+// revisionChanges перечисляет поля, которые различаются в содержимом двух ревизий, по алфавиту
+func revisionChanges(from, to db.JSON) ([]db.FieldChange, error) {
+	fromFields := map[string]any{}
+	if err := json.Unmarshal(from, &fromFields); err != nil {
+		return nil, err
+	}
+	toFields := map[string]any{}
+	if err := json.Unmarshal(to, &toFields); err != nil {
+		return nil, err
+	}
+
+	changes := []db.FieldChange{}
+	for name := range toFields {
+		if _, ok := fromFields[name]; !ok {
+			fromFields[name] = nil
+		}
+	}
+	for name, value := range fromFields {
+		if !reflect.DeepEqual(value, toFields[name]) {
+			changes = append(changes, db.FieldChange{Field: name, From: value, To: toFields[name]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes, nil
+}
@@ -0,0 +1,845 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jmoiron/sqlx"
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// SQLStore хранит проекты и вакансии в SQL-базе (SQLite или PostgreSQL) через sqlx.
+// Запросы пишутся с плейсхолдерами "?" и переводятся в синтаксис драйвера через Rebind.
+type SQLStore struct {
+	db *sqlx.DB
+}
+
+// NewSQLStore создает хранилище поверх уже открытого соединения (обычно database.DB)
+func NewSQLStore(conn *sqlx.DB) *SQLStore {
+	return &SQLStore{db: conn}
+}
+
+// get, selectAll и exec - обертки над методами sqlx с переводом плейсхолдеров
+func (s *SQLStore) get(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return s.db.GetContext(ctx, dest, s.db.Rebind(query), args...)
+}
+
+func (s *SQLStore) selectAll(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return s.db.SelectContext(ctx, dest, s.db.Rebind(query), args...)
+}
+
+func (s *SQLStore) exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return s.db.ExecContext(ctx, s.db.Rebind(query), args...)
+}
+
+// Колонки для SELECT. Необязательные текстовые колонки могут быть NULL,
+// поэтому приводим их к пустой строке, чтобы сканировать в string.
+const (
+	projectColumns = `p.id, p.name, COALESCE(p.description, '') AS description,
+		p.deadline, p.experience, p.archived_at, p.owner_id, p.deleted_at, p.version,
+		p.created_at, p.updated_at, p.created_by, p.updated_by`
+
+	vacancyColumns = `v.id, v.project_id, v.name, COALESCE(v.description, '') AS description,
+		COALESCE(v.field, '') AS field, COALESCE(v.country, '') AS country,
+		COALESCE(v.experience, '') AS experience, v.openings, v.status, v.closed_at, v.deleted_at, v.version,
+		v.created_at, v.updated_at, v.created_by, v.updated_by`
+)
+
+// SQL-выражения для полей сортировки
+var (
+	projectSortColumns = map[string]string{
+		"id":         "p.id",
+		"name":       "p.name",
+		"deadline":   "p.deadline",
+		"created_at": "p.created_at",
+		"updated_at": "p.updated_at",
+	}
+	vacancySortColumns = map[string]string{
+		"id":           "v.id",
+		"name":         "v.name",
+		"field":        "v.field",
+		"country":      "v.country",
+		"project_name": "p.name",
+		"deadline":     "p.deadline",
+		"created_at":   "v.created_at",
+		"updated_at":   "v.updated_at",
+	}
+)
+
+// whereClause - накопитель условий WHERE с аргументами
+type whereClause struct {
+	conditions []string
+	args       []interface{}
+}
+
+// add добавляет условие с плейсхолдерами и соответствующие аргументы
+func (w *whereClause) add(condition string, args ...interface{}) {
+	w.conditions = append(w.conditions, condition)
+	w.args = append(w.args, args...)
+}
+
+// addIn добавляет условие "column IN (...)" по списку значений; пустой список ничего не ограничивает
+func addIn[T any](w *whereClause, column string, values []T) {
+	if len(values) == 0 {
+		return
+	}
+	placeholders := make([]string, len(values))
+	args := make([]interface{}, len(values))
+	for i, value := range values {
+		placeholders[i] = "?"
+		args[i] = value
+	}
+	w.add(column+" IN ("+strings.Join(placeholders, ", ")+")", args...)
+}
+
+// String возвращает готовый фрагмент " WHERE ..." или пустую строку
+func (w *whereClause) String() string {
+	if len(w.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(w.conditions, " AND ")
+}
+
+// orderBy собирает ORDER BY. Для стабильного порядка между страницами
+// всегда добавляем сортировку по id.
+func orderBy(sort Sort, columns map[string]string, idColumn string) string {
+	expr, ok := columns[sort.Field]
+	if !ok {
+		expr = idColumn
+	}
+	direction := "ASC"
+	if sort.Desc {
+		direction = "DESC"
+	}
+	clause := " ORDER BY " + expr + " " + direction
+	if expr != idColumn {
+		clause += ", " + idColumn + " " + direction
+	}
+	return clause
+}
+
+// ListProjects реализует ProjectRepository
+func (s *SQLStore) ListProjects(ctx context.Context, filter ProjectFilter) ([]db.Project, int, error) {
+	var where whereClause
+	where.add("p.deleted_at IS NULL")
+	if !filter.IncludeArchived {
+		where.add("p.archived_at IS NULL")
+	}
+	if filter.Experience != "" {
+		where.add("p.experience = ?", filter.Experience)
+	}
+	if !filter.DeadlineAfter.IsZero() {
+		where.add("p.deadline >= ?", filter.DeadlineAfter)
+	}
+	if !filter.DeadlineBefore.IsZero() {
+		where.add("p.deadline <= ?", filter.DeadlineBefore)
+	}
+
+	var total int
+	if err := s.get(ctx, &total, "SELECT COUNT(*) FROM projects p"+where.String(), where.args...); err != nil {
+		return nil, 0, err
+	}
+
+	projects := []db.Project{}
+	query := "SELECT " + projectColumns + " FROM projects p" + where.String() +
+		orderBy(filter.Sort, projectSortColumns, "p.id") + " LIMIT ? OFFSET ?"
+	args := append(where.args, filter.Page.Limit, filter.Page.Offset)
+	if err := s.selectAll(ctx, &projects, query, args...); err != nil {
+		return nil, 0, err
+	}
+	return projects, total, nil
+}
+
+// CountProjects реализует ProjectRepository
+func (s *SQLStore) CountProjects(ctx context.Context) (int, error) {
+	var count int
+	err := s.get(ctx, &count, "SELECT COUNT(*) FROM projects")
+	return count, err
+}
+
+// GetProject реализует ProjectRepository
+func (s *SQLStore) GetProject(ctx context.Context, id uint) (db.Project, error) {
+	var project db.Project
+	err := s.get(ctx, &project, "SELECT "+projectColumns+" FROM projects p WHERE p.id = ? AND p.deleted_at IS NULL", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return project, ErrNotFound
+	}
+	return project, err
+}
+
+// getProject читает проект через q - базу или открытую транзакцию, в том числе удаленный в корзину
+func getProject(ctx context.Context, q queryer, id uint) (db.Project, error) {
+	var project db.Project
+	err := sqlx.GetContext(ctx, q, &project, q.Rebind("SELECT "+projectColumns+" FROM projects p WHERE p.id = ?"), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return project, ErrNotFound
+	}
+	return project, err
+}
+
+// getVacancy читает вакансию через q - базу или открытую транзакцию, в том числе удаленную в корзину
+func getVacancy(ctx context.Context, q queryer, id uint) (db.Vacancy, error) {
+	var vacancy db.Vacancy
+	err := sqlx.GetContext(ctx, q, &vacancy, q.Rebind("SELECT "+vacancyColumns+" FROM vacancies v WHERE v.id = ?"), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return vacancy, ErrNotFound
+	}
+	return vacancy, err
+}
+
+// CreateProject реализует ProjectRepository
+func (s *SQLStore) CreateProject(ctx context.Context, project *db.Project) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// RETURNING вместо LastInsertId: lib/pq не поддерживает LastInsertId
+	now, actorID := changeStamp(ctx)
+	query := `
+		INSERT INTO projects (name, description, deadline, experience, owner_id, created_at, updated_at, created_by, updated_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+	err = tx.GetContext(ctx, &project.ID, tx.Rebind(query),
+		project.Name, project.Description, project.Deadline, project.Experience, project.OwnerID,
+		now, now, actorID, actorID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if project.OwnerID != nil {
+		if err := upsertMember(ctx, tx, project.ID, *project.OwnerID, db.RoleOwner); err != nil {
+			return err
+		}
+	}
+
+	created, err := getProject(ctx, tx, project.ID)
+	if err != nil {
+		return err
+	}
+	project.Version, project.Timestamps = created.Version, created.Timestamps
+	if err := recordAudit(ctx, tx, db.AuditCreate, "project", project.ID, project.ID, nil, created); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateProject реализует ProjectRepository
+func (s *SQLStore) UpdateProject(ctx context.Context, project *db.Project) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	before, err := getProject(ctx, tx, project.ID)
+	if err != nil {
+		return err
+	}
+	if before.DeletedAt != nil {
+		return ErrNotFound
+	}
+	if err := checkVersion(project.Version, before.Version); err != nil {
+		return err
+	}
+
+	// Условие на версию защищает от изменения, сделанного другим запросом после чтения before
+	query := `
+		UPDATE projects SET
+			name = ?,
+			description = ?,
+			deadline = ?,
+			experience = ?,
+			version = version + 1,
+			updated_at = ?,
+			updated_by = ?
+		WHERE id = ? AND version = ?;
+	`
+	now, actorID := changeStamp(ctx)
+	result, err := tx.ExecContext(ctx, tx.Rebind(query),
+		project.Name, project.Description, project.Deadline, project.Experience, now, actorID, project.ID, before.Version,
+	)
+	if err != nil {
+		return err
+	}
+	if err := expectVersion(result); err != nil {
+		return err
+	}
+
+	after, err := getProject(ctx, tx, project.ID)
+	if err != nil {
+		return err
+	}
+	// Поля не изменились: откатываем транзакцию, чтобы версия осталась прежней
+	unchanged := after
+	unchanged.Version, unchanged.Timestamps = before.Version, before.Timestamps
+	if reflect.DeepEqual(unchanged, before) {
+		return nil
+	}
+	if err := recordAudit(ctx, tx, db.AuditUpdate, "project", project.ID, project.ID, before, after); err != nil {
+		return err
+	}
+	if err := recordRevision(ctx, tx, "project", project.ID, project.ID, before.Content(), after.Content()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteProject реализует ProjectRepository
+func (s *SQLStore) DeleteProject(ctx context.Context, id uint, version int, policy DeletePolicy) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	before, err := getProject(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if before.DeletedAt != nil {
+		return ErrNotFound
+	}
+	if err := checkVersion(version, before.Version); err != nil {
+		return err
+	}
+
+	now, actorID := changeStamp(ctx)
+	switch policy {
+	case DeletePolicyArchive:
+		// Повторная архивация ничего не меняет, дата первой архивации сохраняется
+		if before.ArchivedAt != nil {
+			return nil
+		}
+		query := "UPDATE projects SET archived_at = ?, version = version + 1, updated_at = ?, updated_by = ? WHERE id = ? AND version = ?"
+		result, err := tx.ExecContext(ctx, tx.Rebind(query), now, now, actorID, id, before.Version)
+		if err != nil {
+			return err
+		}
+		if err := expectVersion(result); err != nil {
+			return err
+		}
+		after, err := getProject(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, tx, db.AuditArchive, "project", id, id, before, after); err != nil {
+			return err
+		}
+		return tx.Commit()
+
+	case DeletePolicyRestrict:
+		blocking := []BlockingVacancy{}
+		query := "SELECT id, name FROM vacancies WHERE project_id = ? AND deleted_at IS NULL ORDER BY id"
+		if err := tx.SelectContext(ctx, &blocking, tx.Rebind(query), id); err != nil {
+			return err
+		}
+		if len(blocking) > 0 {
+			return &ProjectHasVacanciesError{Vacancies: blocking}
+		}
+
+	default: // DeletePolicyCascade
+		vacancies := []db.Vacancy{}
+		query := "SELECT " + vacancyColumns + " FROM vacancies v WHERE v.project_id = ? AND v.deleted_at IS NULL ORDER BY v.id"
+		if err := tx.SelectContext(ctx, &vacancies, tx.Rebind(query), id); err != nil {
+			return err
+		}
+		// Вакансии получают ту же отметку, что и проект: по ней RestoreProject
+		// отличает их от вакансий, удаленных раньше по отдельности
+		query = "UPDATE vacancies SET deleted_at = ?, version = version + 1, updated_at = ?, updated_by = ? WHERE project_id = ? AND deleted_at IS NULL"
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), now, now, actorID, id); err != nil {
+			return err
+		}
+		for _, vacancy := range vacancies {
+			if err := recordAudit(ctx, tx, db.AuditDelete, "vacancy", vacancy.ID, id, vacancy, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	query := "UPDATE projects SET deleted_at = ?, version = version + 1, updated_at = ?, updated_by = ? WHERE id = ? AND version = ?"
+	result, err := tx.ExecContext(ctx, tx.Rebind(query), now, now, actorID, id, before.Version)
+	if err != nil {
+		return err
+	}
+	if err := expectVersion(result); err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, tx, db.AuditDelete, "project", id, id, before, nil); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListProjectVacancies реализует VacancyRepository
+func (s *SQLStore) ListProjectVacancies(ctx context.Context, projectID uint, statuses []db.VacancyStatus, order Sort) ([]db.Vacancy, error) {
+	var where whereClause
+	where.add("v.project_id = ?", projectID)
+	where.add("v.deleted_at IS NULL")
+	addIn(&where, "v.status", statuses)
+
+	vacancies := []db.Vacancy{}
+	query := "SELECT " + vacancyColumns + " FROM vacancies v" + where.String() + orderBy(order, vacancySortColumns, "v.id")
+	err := s.selectAll(ctx, &vacancies, query, where.args...)
+	return vacancies, err
+}
+
+// SearchVacancies реализует VacancyRepository
+func (s *SQLStore) SearchVacancies(ctx context.Context, filter VacancyFilter) ([]db.VacancyWithProject, int, error) {
+	var where whereClause
+	// Вакансия, созданная одновременно с удалением проекта, каскадом не удаляется,
+	// поэтому проект в корзине проверяется отдельно
+	where.add("v.deleted_at IS NULL")
+	where.add("p.archived_at IS NULL")
+	where.add("p.deleted_at IS NULL")
+	if filter.Field != "" {
+		where.add("lower(v.field) = lower(?)", filter.Field)
+	}
+	if filter.Country != "" {
+		where.add("lower(v.country) = lower(?)", filter.Country)
+	}
+	if filter.Experience != "" {
+		where.add("v.experience = ?", filter.Experience)
+	}
+	if filter.ProjectID != 0 {
+		where.add("v.project_id = ?", filter.ProjectID)
+	}
+	addIn(&where, "v.status", filter.Statuses)
+
+	from := " FROM vacancies v JOIN projects p ON p.id = v.project_id" + where.String()
+
+	var total int
+	if err := s.get(ctx, &total, "SELECT COUNT(*)"+from, where.args...); err != nil {
+		return nil, 0, err
+	}
+
+	vacancies := []db.VacancyWithProject{}
+	query := "SELECT " + vacancyColumns + ", p.name AS project_name, p.deadline AS project_deadline" + from +
+		orderBy(filter.Sort, vacancySortColumns, "v.id") + " LIMIT ? OFFSET ?"
+	args := append(where.args, filter.Page.Limit, filter.Page.Offset)
+	if err := s.selectAll(ctx, &vacancies, query, args...); err != nil {
+		return nil, 0, err
+	}
+	return vacancies, total, nil
+}
+
+// GetVacancy реализует VacancyRepository
+func (s *SQLStore) GetVacancy(ctx context.Context, id uint) (db.Vacancy, error) {
+	var vacancy db.Vacancy
+	err := s.get(ctx, &vacancy, "SELECT "+vacancyColumns+" FROM vacancies v WHERE v.id = ? AND v.deleted_at IS NULL", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return vacancy, ErrNotFound
+	}
+	return vacancy, err
+}
+
+// CreateVacancy реализует VacancyRepository
+func (s *SQLStore) CreateVacancy(ctx context.Context, vacancy *db.Vacancy) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var projectExists bool
+	if err := tx.GetContext(ctx, &projectExists, tx.Rebind("SELECT EXISTS(SELECT 1 FROM projects WHERE id = ? AND deleted_at IS NULL)"), vacancy.ProjectID); err != nil {
+		return err
+	}
+	if !projectExists {
+		return ErrNotFound
+	}
+
+	query := `
+		INSERT INTO vacancies (project_id, name, description, field, country, experience, openings, status,
+			created_at, updated_at, created_by, updated_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+	if vacancy.Status == "" {
+		vacancy.Status = db.VacancyOpen
+	}
+	now, actorID := changeStamp(ctx)
+	err = tx.GetContext(ctx, &vacancy.ID, tx.Rebind(query),
+		vacancy.ProjectID, vacancy.Name, vacancy.Description,
+		vacancy.Field, vacancy.Country, vacancy.Experience, vacancy.Openings, vacancy.Status,
+		now, now, actorID, actorID,
+	)
+	if err != nil {
+		return err
+	}
+
+	created, err := getVacancy(ctx, tx, vacancy.ID)
+	if err != nil {
+		return err
+	}
+	vacancy.Version, vacancy.Timestamps = created.Version, created.Timestamps
+	if err := recordAudit(ctx, tx, db.AuditCreate, "vacancy", vacancy.ID, vacancy.ProjectID, nil, created); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateVacancy реализует VacancyRepository
+func (s *SQLStore) UpdateVacancy(ctx context.Context, vacancy *db.Vacancy) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	before, err := getVacancy(ctx, tx, vacancy.ID)
+	if err != nil {
+		return err
+	}
+	if before.DeletedAt != nil {
+		return ErrNotFound
+	}
+	if err := checkVersion(vacancy.Version, before.Version); err != nil {
+		return err
+	}
+
+	// Условие на версию защищает от изменения, сделанного другим запросом после чтения before
+	query := `
+		UPDATE vacancies SET
+			name = ?,
+			description = ?,
+			field = ?,
+			country = ?,
+			experience = ?,
+			openings = CASE WHEN ? > 0 THEN ? ELSE openings END,
+			version = version + 1,
+			updated_at = ?,
+			updated_by = ?
+		WHERE id = ? AND version = ?;
+	`
+	now, actorID := changeStamp(ctx)
+	result, err := tx.ExecContext(ctx, tx.Rebind(query),
+		vacancy.Name, vacancy.Description, vacancy.Field, vacancy.Country, vacancy.Experience,
+		vacancy.Openings, vacancy.Openings, now, actorID, vacancy.ID, before.Version,
+	)
+	if err != nil {
+		return err
+	}
+	if err := expectVersion(result); err != nil {
+		return err
+	}
+
+	after, err := getVacancy(ctx, tx, vacancy.ID)
+	if err != nil {
+		return err
+	}
+	// Поля не изменились: откатываем транзакцию, чтобы версия осталась прежней
+	unchanged := after
+	unchanged.Version, unchanged.Timestamps = before.Version, before.Timestamps
+	if reflect.DeepEqual(unchanged, before) {
+		return nil
+	}
+	if err := recordAudit(ctx, tx, db.AuditUpdate, "vacancy", vacancy.ID, before.ProjectID, before, after); err != nil {
+		return err
+	}
+	if err := recordRevision(ctx, tx, "vacancy", vacancy.ID, before.ProjectID, before.Content(), after.Content()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteVacancy реализует VacancyRepository
+func (s *SQLStore) DeleteVacancy(ctx context.Context, id uint, version int) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	before, err := getVacancy(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if before.DeletedAt != nil {
+		return ErrNotFound
+	}
+	if err := checkVersion(version, before.Version); err != nil {
+		return err
+	}
+
+	now, actorID := changeStamp(ctx)
+	query := "UPDATE vacancies SET deleted_at = ?, version = version + 1, updated_at = ?, updated_by = ? WHERE id = ? AND version = ?"
+	result, err := tx.ExecContext(ctx, tx.Rebind(query), now, now, actorID, id, before.Version)
+	if err != nil {
+		return err
+	}
+	if err := expectVersion(result); err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, tx, db.AuditDelete, "vacancy", id, before.ProjectID, before, nil); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// searchDialect - подзапросы поиска и построитель поискового выражения для одного драйвера.
+// Каждый подзапрос возвращает колонки type, id, project_id, name, snippet, rank;
+// чем меньше rank, тем выше результат.
+type searchDialect struct {
+	projectQuery string
+	vacancyQuery string
+	buildMatch   func(input string) string
+}
+
+var searchDialects = map[string]searchDialect{
+	// SQLite: индексы FTS5. Колонка 0 - name, колонка 1 - description.
+	db.DriverSQLite: {
+		projectQuery: `
+		SELECT 'project' AS type, p.id AS id, p.id AS project_id,
+			highlight(projects_fts, 0, '<mark>', '</mark>') AS name,
+			snippet(projects_fts, 1, '<mark>', '</mark>', '…', 16) AS snippet,
+			bm25(projects_fts, 10.0, 1.0) AS rank
+		FROM projects_fts JOIN projects p ON p.id = projects_fts.rowid
+		WHERE projects_fts MATCH ? AND p.archived_at IS NULL AND p.deleted_at IS NULL`,
+		vacancyQuery: `
+		SELECT 'vacancy' AS type, v.id AS id, v.project_id AS project_id,
+			highlight(vacancies_fts, 0, '<mark>', '</mark>') AS name,
+			snippet(vacancies_fts, 1, '<mark>', '</mark>', '…', 16) AS snippet,
+			bm25(vacancies_fts, 10.0, 1.0) AS rank
+		FROM vacancies_fts JOIN vacancies v ON v.id = vacancies_fts.rowid
+		JOIN projects p ON p.id = v.project_id
+		WHERE vacancies_fts MATCH ? AND p.archived_at IS NULL AND p.deleted_at IS NULL AND v.deleted_at IS NULL`,
+		buildMatch: buildMatchQuery,
+	},
+	// PostgreSQL: колонки search_vector с весами A (name) и B (description).
+	// ts_rank растет с релевантностью, поэтому берем его со знаком минус.
+	db.DriverPostgres: {
+		projectQuery: `
+		SELECT 'project' AS type, p.id AS id, p.id AS project_id,
+			ts_headline('simple', p.name, q, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS name,
+			ts_headline('simple', COALESCE(p.description, ''), q, 'StartSel=<mark>, StopSel=</mark>, MaxWords=16, MinWords=8') AS snippet,
+			-ts_rank(p.search_vector, q) AS rank
+		FROM projects p, to_tsquery('simple', ?) q
+		WHERE p.search_vector @@ q AND p.archived_at IS NULL AND p.deleted_at IS NULL`,
+		vacancyQuery: `
+		SELECT 'vacancy' AS type, v.id AS id, v.project_id AS project_id,
+			ts_headline('simple', v.name, q, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS name,
+			ts_headline('simple', COALESCE(v.description, ''), q, 'StartSel=<mark>, StopSel=</mark>, MaxWords=16, MinWords=8') AS snippet,
+			-ts_rank(v.search_vector, q) AS rank
+		FROM vacancies v JOIN projects p ON p.id = v.project_id, to_tsquery('simple', ?) q
+		WHERE v.search_vector @@ q AND p.archived_at IS NULL AND p.deleted_at IS NULL AND v.deleted_at IS NULL`,
+		buildMatch: buildTSQuery,
+	},
+}
+
+// buildMatchQuery превращает пользовательский ввод в безопасное FTS5-выражение.
+// Каждое слово берется в кавычки (чтобы символы вроде "-" или ":" не ломали синтаксис FTS5)
+// и ищется по префиксу; слова объединяются через неявный AND.
+func buildMatchQuery(input string) string {
+	terms := strings.Fields(input)
+	for i, term := range terms {
+		terms[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"*`
+	}
+	return strings.Join(terms, " ")
+}
+
+// buildTSQuery превращает пользовательский ввод в выражение to_tsquery.
+// Синтаксис tsquery нельзя экранировать кавычками, поэтому оставляем в словах
+// только буквы и цифры; каждое слово ищется по префиксу (:*), слова объединяются через &.
+func buildTSQuery(input string) string {
+	terms := strings.FieldsFunc(input, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+// ChangeVacancyStatus реализует VacancyRepository
+func (s *SQLStore) ChangeVacancyStatus(ctx context.Context, id uint, to db.VacancyStatus, version int, today db.Date) (db.Vacancy, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return db.Vacancy{}, err
+	}
+	defer tx.Rollback()
+
+	var row struct {
+		db.Vacancy
+		Deadline db.Date `db:"deadline"`
+	}
+	query := "SELECT " + vacancyColumns + ", p.deadline" +
+		" FROM vacancies v JOIN projects p ON p.id = v.project_id WHERE v.id = ? AND v.deleted_at IS NULL"
+	err = tx.GetContext(ctx, &row, tx.Rebind(query), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.Vacancy{}, ErrNotFound
+	}
+	if err != nil {
+		return db.Vacancy{}, err
+	}
+
+	vacancy := row.Vacancy
+	if err := checkVersion(version, vacancy.Version); err != nil {
+		return db.Vacancy{}, err
+	}
+	if !vacancy.Status.CanBecome(to) {
+		return db.Vacancy{}, &InvalidVacancyTransitionError{From: vacancy.Status, To: to}
+	}
+	if to == db.VacancyOpen {
+		if vacancy.Openings == 0 {
+			return db.Vacancy{}, ErrNoOpenings
+		}
+		if row.Deadline.Before(today.Time) {
+			return db.Vacancy{}, ErrDeadlinePassed
+		}
+	}
+
+	now, actorID := changeStamp(ctx)
+	var closedAt *time.Time
+	if to == db.VacancyClosed {
+		closedAt = &now
+	}
+	// Условие на прочитанную версию защищает от одновременного изменения вакансии другим запросом
+	query = "UPDATE vacancies SET status = ?, closed_at = ?, version = version + 1, updated_at = ?, updated_by = ? WHERE id = ? AND version = ?"
+	result, err := tx.ExecContext(ctx, tx.Rebind(query), to, closedAt, now, actorID, id, vacancy.Version)
+	if err != nil {
+		return db.Vacancy{}, err
+	}
+	if err := expectVersion(result); err != nil {
+		return db.Vacancy{}, err
+	}
+
+	vacancy.Status = to
+	vacancy.ClosedAt = closedAt
+	vacancy.Version++
+	vacancy.UpdatedAt, vacancy.UpdatedBy = now, actorID
+	if err := recordAudit(ctx, tx, db.AuditUpdate, "vacancy", id, vacancy.ProjectID, row.Vacancy, vacancy); err != nil {
+		return db.Vacancy{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return db.Vacancy{}, err
+	}
+	return vacancy, nil
+}
+
+// ExpireVacancies реализует VacancyRepository.
+// Каждая вакансия обновляется отдельно и попадает в журнал аудита без автора:
+// срок истекает по расписанию, а не по действию пользователя.
+func (s *SQLStore) ExpireVacancies(ctx context.Context, today db.Date) (int, error) {
+	ctx = WithActor(ctx, Actor{})
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	candidates := []db.Vacancy{}
+	query := "SELECT " + vacancyColumns + `
+		FROM vacancies v JOIN projects p ON p.id = v.project_id
+		WHERE v.status IN ('open', 'paused') AND v.deleted_at IS NULL AND p.deleted_at IS NULL AND p.deadline < ?
+	`
+	if err := tx.SelectContext(ctx, &candidates, tx.Rebind(query), today); err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	now := time.Now().UTC()
+	for _, before := range candidates {
+		// Условие на прежний статус пропускает вакансии, статус которых успели сменить
+		query := "UPDATE vacancies SET status = 'expired', version = version + 1, updated_at = ?, updated_by = NULL WHERE id = ? AND status = ?"
+		result, err := tx.ExecContext(ctx, tx.Rebind(query), now, before.ID, before.Status)
+		if err != nil {
+			return 0, err
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return 0, err
+		} else if affected == 0 {
+			continue
+		}
+
+		after := before
+		after.Status = db.VacancyExpired
+		after.Version++
+		after.UpdatedAt, after.UpdatedBy = now, nil
+		if err := recordAudit(ctx, tx, db.AuditUpdate, "vacancy", before.ID, before.ProjectID, before, after); err != nil {
+			return 0, err
+		}
+		expired++
+	}
+	return expired, tx.Commit()
+}
+
+// Search реализует SearchRepository: FTS5 в SQLite, tsvector в PostgreSQL
+func (s *SQLStore) Search(ctx context.Context, query SearchQuery) ([]db.SearchResult, int, error) {
+	dialect, ok := searchDialects[s.db.DriverName()]
+	if !ok {
+		return nil, 0, fmt.Errorf("full-text search is not supported for driver %s", s.db.DriverName())
+	}
+	match := dialect.buildMatch(query.Text)
+	if match == "" {
+		// В запросе не осталось слов (например, только знаки препинания)
+		return []db.SearchResult{}, 0, nil
+	}
+
+	var subqueries []string
+	switch query.Type {
+	case "project":
+		subqueries = []string{dialect.projectQuery}
+	case "vacancy":
+		subqueries = []string{dialect.vacancyQuery}
+	default:
+		subqueries = []string{dialect.projectQuery, dialect.vacancyQuery}
+	}
+	union := strings.Join(subqueries, " UNION ALL ")
+	args := make([]interface{}, len(subqueries))
+	for i := range args {
+		args[i] = match
+	}
+
+	var total int
+	if err := s.get(ctx, &total, "SELECT COUNT(*) FROM ("+union+") AS results", args...); err != nil {
+		return nil, 0, err
+	}
+
+	results := []db.SearchResult{}
+	sqlQuery := "SELECT * FROM (" + union + ") AS results ORDER BY rank, type, id LIMIT ? OFFSET ?"
+	args = append(args, query.Page.Limit, query.Page.Offset)
+	if err := s.selectAll(ctx, &results, sqlQuery, args...); err != nil {
+		return nil, 0, err
+	}
+	return results, total, nil
+}
+
+// expectAffected превращает "ни одна строка не изменена" в ErrNotFound
+func expectAffected(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// expectVersion - как expectAffected для UPDATE с условием на version:
+// если ни одна строка не изменилась, запись успел изменить другой запрос
+func expectVersion(result sql.Result) error {
+	if err := expectAffected(result); errors.Is(err, ErrNotFound) {
+		return ErrVersionMismatch
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Проверяем на этапе компиляции, что SQLStore реализует все интерфейсы
+var (
+	_ ProjectRepository = (*SQLStore)(nil)
+	_ VacancyRepository = (*SQLStore)(nil)
+	_ SearchRepository  = (*SQLStore)(nil)
+)
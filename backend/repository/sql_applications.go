@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// applicationColumns - колонки отклика вместе с проектом и названием вакансии.
+// Используется с FROM applications a JOIN vacancies v ON v.id = a.vacancy_id.
+const applicationColumns = `a.id, a.vacancy_id, v.project_id, v.name AS vacancy_name,
+	a.name, a.email, a.phone, a.cover_letter, a.status, a.created_at, a.updated_at`
+
+const applicationFrom = " FROM applications a JOIN vacancies v ON v.id = a.vacancy_id"
+
+// getApplication читает отклик через q - базу или открытую транзакцию
+func getApplication(ctx context.Context, q queryer, id uint) (db.Application, error) {
+	var application db.Application
+	err := sqlx.GetContext(ctx, q, &application, q.Rebind("SELECT "+applicationColumns+applicationFrom+" WHERE a.id = ?"), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return application, ErrNotFound
+	}
+	return application, err
+}
+
+// insertApplicationEvent дописывает запись в историю статусов отклика
+func insertApplicationEvent(ctx context.Context, q queryer, event *db.ApplicationEvent) error {
+	var from interface{}
+	if event.FromStatus != "" {
+		from = event.FromStatus
+	}
+	query := `
+		INSERT INTO application_events (application_id, from_status, to_status, note, changed_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+	return sqlx.GetContext(ctx, q, &event.ID, q.Rebind(query),
+		event.ApplicationID, from, event.ToStatus, event.Note, event.ChangedBy, event.CreatedAt,
+	)
+}
+
+// CreateApplication реализует ApplicationRepository
+func (s *SQLStore) CreateApplication(ctx context.Context, application *db.Application) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var vacancy db.Vacancy
+	err = tx.GetContext(ctx, &vacancy, tx.Rebind("SELECT "+vacancyColumns+" FROM vacancies v WHERE v.id = ? AND v.deleted_at IS NULL"), application.VacancyID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if vacancy.Status != db.VacancyOpen {
+		return ErrVacancyNotOpen
+	}
+
+	now := time.Now().UTC()
+	application.ProjectID = vacancy.ProjectID
+	application.VacancyName = vacancy.Name
+	application.Status = db.ApplicationSubmitted
+	application.CreatedAt, application.UpdatedAt = now, now
+
+	query := `
+		INSERT INTO applications (vacancy_id, name, email, phone, cover_letter, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+	err = tx.GetContext(ctx, &application.ID, tx.Rebind(query),
+		application.VacancyID, application.Name, application.Email, application.Phone,
+		application.CoverLetter, application.Status, application.CreatedAt, application.UpdatedAt,
+	)
+	if isUniqueViolation(err) {
+		return ErrAlreadyExists
+	}
+	if err != nil {
+		return err
+	}
+
+	event := db.ApplicationEvent{ApplicationID: application.ID, ToStatus: application.Status, CreatedAt: now}
+	if err := insertApplicationEvent(ctx, tx, &event); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetApplication реализует ApplicationRepository
+func (s *SQLStore) GetApplication(ctx context.Context, id uint) (db.Application, error) {
+	return getApplication(ctx, s.db, id)
+}
+
+// ListApplications реализует ApplicationRepository
+func (s *SQLStore) ListApplications(ctx context.Context, filter ApplicationFilter) ([]db.Application, int, error) {
+	var where whereClause
+	if filter.ProjectID != 0 {
+		where.add("v.project_id = ?", filter.ProjectID)
+	}
+	if filter.VacancyID != 0 {
+		where.add("a.vacancy_id = ?", filter.VacancyID)
+	}
+	if filter.Status != "" {
+		where.add("a.status = ?", filter.Status)
+	}
+	from := applicationFrom + where.String()
+
+	var total int
+	if err := s.get(ctx, &total, "SELECT COUNT(*)"+from, where.args...); err != nil {
+		return nil, 0, err
+	}
+
+	applications := []db.Application{}
+	query := "SELECT " + applicationColumns + from + " ORDER BY a.created_at DESC, a.id DESC LIMIT ? OFFSET ?"
+	args := append(where.args, filter.Page.Limit, filter.Page.Offset)
+	if err := s.selectAll(ctx, &applications, query, args...); err != nil {
+		return nil, 0, err
+	}
+	return applications, total, nil
+}
+
+// ChangeApplicationStatus реализует ApplicationRepository.
+// Статус меняется условием WHERE status = <прочитанный статус>, поэтому два
+// одновременных перехода не проскочат оба: второй перечитает новый статус и
+// проверит переход заново. Статусы движутся только вперед, так что цикл конечен.
+func (s *SQLStore) ChangeApplicationStatus(ctx context.Context, id uint, event *db.ApplicationEvent) (db.Application, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return db.Application{}, err
+	}
+	defer tx.Rollback()
+
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now().UTC()
+	}
+	for {
+		application, err := getApplication(ctx, tx, id)
+		if err != nil {
+			return db.Application{}, err
+		}
+		if !application.Status.CanBecome(event.ToStatus) {
+			return db.Application{}, &InvalidTransitionError{From: application.Status, To: event.ToStatus}
+		}
+
+		result, err := tx.ExecContext(ctx, tx.Rebind("UPDATE applications SET status = ?, updated_at = ? WHERE id = ? AND status = ?"),
+			event.ToStatus, event.CreatedAt, id, application.Status)
+		if err != nil {
+			return db.Application{}, err
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return db.Application{}, err
+		} else if affected == 0 {
+			continue // статус успели поменять - перечитываем и проверяем переход заново
+		}
+
+		event.ApplicationID = id
+		event.FromStatus = application.Status
+		if err := insertApplicationEvent(ctx, tx, event); err != nil {
+			return db.Application{}, err
+		}
+		if err := tx.Commit(); err != nil {
+			return db.Application{}, err
+		}
+		application.Status = event.ToStatus
+		application.UpdatedAt = event.CreatedAt
+		return application, nil
+	}
+}
+
+// ListApplicationEvents реализует ApplicationRepository
+func (s *SQLStore) ListApplicationEvents(ctx context.Context, applicationID uint) ([]db.ApplicationEvent, error) {
+	events := []db.ApplicationEvent{}
+	query := `
+		SELECT id, application_id, COALESCE(from_status, '') AS from_status, to_status, note, changed_by, created_at
+		FROM application_events
+		WHERE application_id = ?
+		ORDER BY created_at, id
+	`
+	err := s.selectAll(ctx, &events, query, applicationID)
+	return events, err
+}
+
+var _ ApplicationRepository = (*SQLStore)(nil)
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// editableBy - условие "пользователь может изменять проект с ID из projectColumn": он
+// владелец или менеджер (см. handlers.authorizeProject).
+// Условие принимает один аргумент - ID пользователя.
+func editableBy(projectColumn string) string {
+	return `EXISTS(SELECT 1 FROM project_members m WHERE m.project_id = ` + projectColumn + ` AND m.user_id = ? AND m.role IN ('owner', 'manager'))`
+}
+
+// recordAudit пишет событие журнала аудита через q - транзакцию, в которой сделано изменение.
+// before и after - состояние записи до и после; nil при создании и удалении.
+func recordAudit(ctx context.Context, q queryer, action db.AuditAction, entityType string, entityID, projectID uint, before, after any) error {
+	event, changed, err := newAuditEvent(ctx, action, entityType, entityID, projectID, before, after)
+	if err != nil || !changed {
+		return err
+	}
+	query := `
+		INSERT INTO audit_events (actor_id, action, entity_type, entity_id, project_id, before_json, after_json, request_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+	return sqlx.GetContext(ctx, q, &event.ID, q.Rebind(query),
+		event.ActorID, event.Action, event.EntityType, event.EntityID, event.ProjectID,
+		event.Before, event.After, event.RequestID, event.CreatedAt,
+	)
+}
+
+// ListAuditEvents реализует AuditRepository
+func (s *SQLStore) ListAuditEvents(ctx context.Context, filter AuditFilter) ([]db.AuditEvent, int, error) {
+	var where whereClause
+	where.add(editableBy("e.project_id"), filter.UserID)
+	if filter.ActorID != 0 {
+		where.add("e.actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		where.add("e.action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		where.add("e.entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != 0 {
+		where.add("e.entity_id = ?", filter.EntityID)
+	}
+	if filter.ProjectID != 0 {
+		where.add("e.project_id = ?", filter.ProjectID)
+	}
+	if !filter.Since.IsZero() {
+		where.add("e.created_at >= ?", filter.Since.UTC())
+	}
+	if !filter.Until.IsZero() {
+		where.add("e.created_at < ?", filter.Until.UTC())
+	}
+
+	var total int
+	if err := s.get(ctx, &total, "SELECT COUNT(*) FROM audit_events e"+where.String(), where.args...); err != nil {
+		return nil, 0, err
+	}
+
+	events := []db.AuditEvent{}
+	query := `
+		SELECT e.id, e.actor_id, COALESCE(u.name, '') AS actor_name, e.action, e.entity_type, e.entity_id,
+			e.project_id, e.before_json, e.after_json, e.request_id, e.created_at
+		FROM audit_events e LEFT JOIN users u ON u.id = e.actor_id` + where.String() +
+		" ORDER BY e.created_at DESC, e.id DESC LIMIT ? OFFSET ?"
+	args := append(where.args, filter.Page.Limit, filter.Page.Offset)
+	if err := s.selectAll(ctx, &events, query, args...); err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+var _ AuditRepository = (*SQLStore)(nil)
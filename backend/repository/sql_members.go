@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// isForeignKeyViolation распознает нарушение внешнего ключа в ошибках обоих драйверов
+func isForeignKeyViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintForeignKey
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23503" // foreign_key_violation
+	}
+	return false
+}
+
+// upsertMember добавляет участника или меняет его роль.
+// ON CONFLICT ... DO UPDATE поддерживают и SQLite, и PostgreSQL.
+func upsertMember(ctx context.Context, tx *sqlx.Tx, projectID, userID uint, role db.ProjectRole) error {
+	query := `
+		INSERT INTO project_members (project_id, user_id, role, created_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (project_id, user_id) DO UPDATE SET role = excluded.role
+	`
+	_, err := tx.ExecContext(ctx, tx.Rebind(query), projectID, userID, role, time.Now().UTC())
+	if isForeignKeyViolation(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// GetProjectAccess реализует MemberRepository
+func (s *SQLStore) GetProjectAccess(ctx context.Context, projectID, userID uint) (ProjectAccess, error) {
+	var row struct {
+		Exists bool           `db:"project_exists"`
+		Role   sql.NullString `db:"role"`
+	}
+	query := `
+		SELECT
+			EXISTS(SELECT 1 FROM projects WHERE id = ?) AS project_exists,
+			(SELECT role FROM project_members WHERE project_id = ? AND user_id = ?) AS role
+	`
+	if err := s.get(ctx, &row, query, projectID, projectID, userID); err != nil {
+		return ProjectAccess{}, err
+	}
+	if !row.Exists {
+		return ProjectAccess{}, ErrNotFound
+	}
+	return ProjectAccess{Role: db.ProjectRole(row.Role.String)}, nil
+}
+
+// ListProjectMembers реализует MemberRepository
+func (s *SQLStore) ListProjectMembers(ctx context.Context, projectID uint) ([]db.ProjectMember, error) {
+	members := []db.ProjectMember{}
+	query := `
+		SELECT m.project_id, m.user_id, m.role, u.email, u.name, m.created_at
+		FROM project_members m JOIN users u ON u.id = m.user_id
+		WHERE m.project_id = ?
+		ORDER BY CASE m.role WHEN 'owner' THEN 0 WHEN 'manager' THEN 1 ELSE 2 END, m.user_id
+	`
+	err := s.selectAll(ctx, &members, query, projectID)
+	return members, err
+}
+
+// SetProjectMember реализует MemberRepository
+func (s *SQLStore) SetProjectMember(ctx context.Context, projectID, userID uint, role db.ProjectRole) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := upsertMember(ctx, tx, projectID, userID, role); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RemoveProjectMember реализует MemberRepository
+func (s *SQLStore) RemoveProjectMember(ctx context.Context, projectID, userID uint) error {
+	result, err := s.exec(ctx, "DELETE FROM project_members WHERE project_id = ? AND user_id = ?", projectID, userID)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+var _ MemberRepository = (*SQLStore)(nil)
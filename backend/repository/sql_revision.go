@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// recordRevision сохраняет через q - транзакцию изменения - ревизии содержимого записи
+// (см. nextRevisions). before и after - db.ProjectContent или db.VacancyContent.
+func recordRevision(ctx context.Context, q queryer, entityType string, entityID, projectID uint, before, after any) error {
+	var last int
+	query := "SELECT COALESCE(MAX(revision), 0) FROM revisions WHERE entity_type = ? AND entity_id = ?"
+	if err := sqlx.GetContext(ctx, q, &last, q.Rebind(query), entityType, entityID); err != nil {
+		return err
+	}
+
+	revisions, err := nextRevisions(ctx, entityType, entityID, projectID, last, before, after)
+	if err != nil {
+		return err
+	}
+	insert := q.Rebind(`
+		INSERT INTO revisions (entity_type, entity_id, project_id, revision, content, author_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`)
+	for _, r := range revisions {
+		err := sqlx.GetContext(ctx, q, &r.ID, insert,
+			r.EntityType, r.EntityID, r.ProjectID, r.Revision, r.Content, r.AuthorID, r.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const revisionColumns = `
+	r.id, r.entity_type, r.entity_id, r.project_id, r.revision, r.content,
+	r.author_id, COALESCE(u.name, '') AS author_name, r.created_at
+`
+
+// ListRevisions реализует RevisionRepository
+func (s *SQLStore) ListRevisions(ctx context.Context, entityType string, entityID uint, page Page) ([]db.Revision, int, error) {
+	var total int
+	query := "SELECT COUNT(*) FROM revisions WHERE entity_type = ? AND entity_id = ?"
+	if err := s.get(ctx, &total, query, entityType, entityID); err != nil {
+		return nil, 0, err
+	}
+
+	// На одну ревизию больше: с ней сравнивается последняя ревизия страницы
+	revisions := []db.Revision{}
+	query = "SELECT " + revisionColumns + " FROM revisions r LEFT JOIN users u ON u.id = r.author_id" +
+		" WHERE r.entity_type = ? AND r.entity_id = ? ORDER BY r.revision DESC LIMIT ? OFFSET ?"
+	if err := s.selectAll(ctx, &revisions, query, entityType, entityID, page.Limit+1, page.Offset); err != nil {
+		return nil, 0, err
+	}
+	if err := setRevisionChanges(revisions); err != nil {
+		return nil, 0, err
+	}
+	if len(revisions) > page.Limit {
+		revisions = revisions[:page.Limit]
+	}
+	return revisions, total, nil
+}
+
+// GetRevision реализует RevisionRepository
+func (s *SQLStore) GetRevision(ctx context.Context, entityType string, entityID uint, revision int) (db.Revision, error) {
+	var r db.Revision
+	query := "SELECT " + revisionColumns + " FROM revisions r LEFT JOIN users u ON u.id = r.author_id" +
+		" WHERE r.entity_type = ? AND r.entity_id = ? AND r.revision = ?"
+	err := s.get(ctx, &r, query, entityType, entityID, revision)
+	if errors.Is(err, sql.ErrNoRows) {
+		return r, ErrNotFound
+	}
+	r.Changes = []db.FieldChange{}
+	return r, err
+}
+
+var _ RevisionRepository = (*SQLStore)(nil)
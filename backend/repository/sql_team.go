@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// ListTeamMembers реализует TeamRepository
+func (s *SQLStore) ListTeamMembers(ctx context.Context, projectID uint) ([]db.TeamMember, error) {
+	members := []db.TeamMember{}
+	query := `
+		SELECT id, project_id, vacancy_id, name, position, created_at
+		FROM team_members
+		WHERE project_id = ?
+		ORDER BY created_at, id
+	`
+	err := s.selectAll(ctx, &members, query, projectID)
+	return members, err
+}
+
+// AddTeamMember реализует TeamRepository.
+// Место списывается условиями openings > 0 и status = 'open' в самом UPDATE, поэтому
+// два одновременных найма не займут одно последнее место.
+func (s *SQLStore) AddTeamMember(ctx context.Context, member *db.TeamMember) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var projectExists bool
+	if err := tx.GetContext(ctx, &projectExists, tx.Rebind("SELECT EXISTS(SELECT 1 FROM projects WHERE id = ? AND deleted_at IS NULL)"), member.ProjectID); err != nil {
+		return err
+	}
+	if !projectExists {
+		return ErrNotFound
+	}
+
+	if member.CreatedAt.IsZero() {
+		member.CreatedAt = time.Now().UTC()
+	}
+
+	if member.VacancyID != nil {
+		before, err := getVacancy(ctx, tx, *member.VacancyID)
+		if err != nil {
+			return err
+		}
+		if before.ProjectID != member.ProjectID || before.DeletedAt != nil {
+			return ErrNotFound
+		}
+		if err := checkHire(before); err != nil {
+			return err
+		}
+
+		query := `
+			UPDATE vacancies SET
+				openings = openings - 1,
+				status = CASE WHEN openings = 1 THEN 'closed' ELSE status END,
+				closed_at = CASE WHEN openings = 1 THEN ? ELSE closed_at END,
+				version = version + 1,
+				updated_at = ?,
+				updated_by = ?
+			WHERE id = ? AND openings > 0 AND status = 'open'
+		`
+		result, err := tx.ExecContext(ctx, tx.Rebind(query), member.CreatedAt, member.CreatedAt, actorFrom(ctx).UserID, *member.VacancyID)
+		if err != nil {
+			return err
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			// Вакансию изменил другой запрос после чтения before
+			current, err := getVacancy(ctx, tx, *member.VacancyID)
+			if err != nil {
+				return err
+			}
+			return checkHire(current)
+		}
+		if err := recordVacancyChange(ctx, tx, before); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO team_members (project_id, vacancy_id, name, position, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		RETURNING id
+	`
+	err = tx.GetContext(ctx, &member.ID, tx.Rebind(query),
+		member.ProjectID, member.VacancyID, member.Name, member.Position, member.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RemoveTeamMember реализует TeamRepository.
+// Вакансия открывается снова, только если ее закрыл последний найм
+// (мест не осталось); закрытую вручную вакансию статус не меняет.
+func (s *SQLStore) RemoveTeamMember(ctx context.Context, projectID, memberID uint) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var vacancyID sql.NullInt64
+	err = tx.GetContext(ctx, &vacancyID, tx.Rebind("SELECT vacancy_id FROM team_members WHERE id = ? AND project_id = ?"),
+		memberID, projectID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind("DELETE FROM team_members WHERE id = ?"), memberID); err != nil {
+		return err
+	}
+	if vacancyID.Valid {
+		before, err := getVacancy(ctx, tx, uint(vacancyID.Int64))
+		if err != nil {
+			return err
+		}
+		query := `
+			UPDATE vacancies SET
+				openings = openings + 1,
+				status = CASE WHEN status = 'closed' AND openings = 0 THEN 'open' ELSE status END,
+				closed_at = CASE WHEN status = 'closed' AND openings = 0 THEN NULL ELSE closed_at END,
+				version = version + 1,
+				updated_at = ?,
+				updated_by = ?
+			WHERE id = ?
+		`
+		now, actorID := changeStamp(ctx)
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), now, actorID, before.ID); err != nil {
+			return err
+		}
+		if err := recordVacancyChange(ctx, tx, before); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// checkHire проверяет, что на вакансию можно принять человека:
+// ErrNoOpenings - мест не осталось, ErrVacancyNotOpen - вакансия не в статусе open
+func checkHire(vacancy db.Vacancy) error {
+	if vacancy.Openings <= 0 {
+		return ErrNoOpenings
+	}
+	if vacancy.Status != db.VacancyOpen {
+		return ErrVacancyNotOpen
+	}
+	return nil
+}
+
+// recordVacancyChange записывает в журнал изменение вакансии, состояние которой
+// до изменения - before; новое состояние перечитывается в той же транзакции
+func recordVacancyChange(ctx context.Context, q queryer, before db.Vacancy) error {
+	after, err := getVacancy(ctx, q, before.ID)
+	if err != nil {
+		return err
+	}
+	return recordAudit(ctx, q, db.AuditUpdate, "vacancy", before.ID, before.ProjectID, before, after)
+}
+
+// GetProjectStaffing реализует TeamRepository
+func (s *SQLStore) GetProjectStaffing(ctx context.Context, projectID uint) (db.ProjectStaffing, error) {
+	var staffing db.ProjectStaffing
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM team_members WHERE project_id = ?) AS headcount,
+			(SELECT COALESCE(SUM(openings), 0) FROM vacancies WHERE project_id = ? AND status = 'open' AND deleted_at IS NULL) AS open_positions
+	`
+	err := s.get(ctx, &staffing, query, projectID, projectID)
+	return staffing, err
+}
+
+var _ TeamRepository = (*SQLStore)(nil)
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// Подзапросы корзины; каждый возвращает колонки type, id, project_id, name, deleted_at.
+// Видны только записи проектов, которые пользователь может изменять.
+var (
+	trashProjectQuery = `
+		SELECT 'project' AS type, p.id AS id, p.id AS project_id, p.name AS name, p.deleted_at AS deleted_at
+		FROM projects p
+		WHERE p.deleted_at IS NOT NULL AND ` + editableBy("p.id")
+
+	// Вакансии, удаленные вместе с проектом (с той же отметкой), показываются только в составе проекта
+	trashVacancyQuery = `
+		SELECT 'vacancy' AS type, v.id AS id, v.project_id AS project_id, v.name AS name, v.deleted_at AS deleted_at
+		FROM vacancies v JOIN projects p ON p.id = v.project_id
+		WHERE v.deleted_at IS NOT NULL AND (p.deleted_at IS NULL OR v.deleted_at <> p.deleted_at) AND ` + editableBy("p.id")
+)
+
+// ListTrash реализует TrashRepository
+func (s *SQLStore) ListTrash(ctx context.Context, filter TrashFilter) ([]db.TrashItem, int, error) {
+	var subqueries []string
+	switch filter.Type {
+	case "project":
+		subqueries = []string{trashProjectQuery}
+	case "vacancy":
+		subqueries = []string{trashVacancyQuery}
+	default:
+		subqueries = []string{trashProjectQuery, trashVacancyQuery}
+	}
+	union := strings.Join(subqueries, " UNION ALL ")
+	args := make([]interface{}, len(subqueries))
+	for i := range args {
+		args[i] = filter.UserID
+	}
+
+	var total int
+	if err := s.get(ctx, &total, "SELECT COUNT(*) FROM ("+union+") AS trash", args...); err != nil {
+		return nil, 0, err
+	}
+
+	items := []db.TrashItem{}
+	query := "SELECT * FROM (" + union + ") AS trash ORDER BY deleted_at DESC, type, id LIMIT ? OFFSET ?"
+	args = append(args, filter.Page.Limit, filter.Page.Offset)
+	if err := s.selectAll(ctx, &items, query, args...); err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// GetTrashItem реализует TrashRepository
+func (s *SQLStore) GetTrashItem(ctx context.Context, itemType string, id uint) (db.TrashItem, error) {
+	var query string
+	switch itemType {
+	case "project":
+		query = "SELECT 'project' AS type, id, id AS project_id, name, deleted_at FROM projects WHERE id = ? AND deleted_at IS NOT NULL"
+	case "vacancy":
+		query = "SELECT 'vacancy' AS type, id, project_id, name, deleted_at FROM vacancies WHERE id = ? AND deleted_at IS NOT NULL"
+	default:
+		return db.TrashItem{}, ErrNotFound
+	}
+
+	var item db.TrashItem
+	err := s.get(ctx, &item, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return item, ErrNotFound
+	}
+	return item, err
+}
+
+// RestoreProject реализует TrashRepository
+func (s *SQLStore) RestoreProject(ctx context.Context, id uint) (db.Project, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return db.Project{}, err
+	}
+	defer tx.Rollback()
+
+	before, err := getProject(ctx, tx, id)
+	if err != nil {
+		return db.Project{}, err
+	}
+	if before.DeletedAt == nil {
+		return db.Project{}, ErrNotFound
+	}
+
+	// Вакансии, удаленные вместе с проектом, - с той же отметкой deleted_at
+	vacancies := []db.Vacancy{}
+	query := "SELECT " + vacancyColumns + " FROM vacancies v" +
+		" WHERE v.project_id = ? AND v.deleted_at = (SELECT deleted_at FROM projects WHERE id = ?) ORDER BY v.id"
+	if err := tx.SelectContext(ctx, &vacancies, tx.Rebind(query), id, id); err != nil {
+		return db.Project{}, err
+	}
+	now, actorID := changeStamp(ctx)
+	for _, vacancy := range vacancies {
+		query := "UPDATE vacancies SET deleted_at = NULL, version = version + 1, updated_at = ?, updated_by = ? WHERE id = ?"
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), now, actorID, vacancy.ID); err != nil {
+			return db.Project{}, err
+		}
+		restored := vacancy
+		restored.DeletedAt = nil
+		restored.Version++
+		restored.UpdatedAt, restored.UpdatedBy = now, actorID
+		if err := recordAudit(ctx, tx, db.AuditRestore, "vacancy", vacancy.ID, id, vacancy, restored); err != nil {
+			return db.Project{}, err
+		}
+	}
+
+	query = "UPDATE projects SET deleted_at = NULL, version = version + 1, updated_at = ?, updated_by = ? WHERE id = ?"
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), now, actorID, id); err != nil {
+		return db.Project{}, err
+	}
+	project := before
+	project.DeletedAt = nil
+	project.Version++
+	project.UpdatedAt, project.UpdatedBy = now, actorID
+	if err := recordAudit(ctx, tx, db.AuditRestore, "project", id, id, before, project); err != nil {
+		return db.Project{}, err
+	}
+	return project, tx.Commit()
+}
+
+// RestoreVacancy реализует TrashRepository
+func (s *SQLStore) RestoreVacancy(ctx context.Context, id uint) (db.Vacancy, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return db.Vacancy{}, err
+	}
+	defer tx.Rollback()
+
+	var row struct {
+		db.Vacancy
+		ProjectDeletedAt *time.Time `db:"project_deleted_at"`
+	}
+	query := "SELECT " + vacancyColumns + ", p.deleted_at AS project_deleted_at" +
+		" FROM vacancies v JOIN projects p ON p.id = v.project_id WHERE v.id = ? AND v.deleted_at IS NOT NULL"
+	err = tx.GetContext(ctx, &row, tx.Rebind(query), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.Vacancy{}, ErrNotFound
+	}
+	if err != nil {
+		return db.Vacancy{}, err
+	}
+	if row.ProjectDeletedAt != nil {
+		return db.Vacancy{}, ErrProjectDeleted
+	}
+
+	now, actorID := changeStamp(ctx)
+	query = "UPDATE vacancies SET deleted_at = NULL, version = version + 1, updated_at = ?, updated_by = ? WHERE id = ?"
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), now, actorID, id); err != nil {
+		return db.Vacancy{}, err
+	}
+	vacancy := row.Vacancy
+	vacancy.DeletedAt = nil
+	vacancy.Version++
+	vacancy.UpdatedAt, vacancy.UpdatedBy = now, actorID
+	if err := recordAudit(ctx, tx, db.AuditRestore, "vacancy", id, vacancy.ProjectID, row.Vacancy, vacancy); err != nil {
+		return db.Vacancy{}, err
+	}
+	return vacancy, tx.Commit()
+}
+
+// PurgeTrash реализует TrashRepository.
+// Отклики удаляются каскадно вместе с вакансиями, участники, команда и ревизии - вместе с проектами.
+func (s *SQLStore) PurgeTrash(ctx context.Context, before time.Time) (PurgeResult, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return PurgeResult{}, err
+	}
+	defer tx.Rollback()
+
+	var purged PurgeResult
+	// Вакансии удаляем первыми: внешний ключ на проект не каскадный.
+	// Ревизии вакансий ссылаются только на проект, поэтому удаляем их явно.
+	expired := "deleted_at < ? OR project_id IN (SELECT id FROM projects WHERE deleted_at < ?)"
+	query := "DELETE FROM revisions WHERE entity_type = 'vacancy' AND entity_id IN (SELECT id FROM vacancies WHERE " + expired + ")"
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), before, before); err != nil {
+		return PurgeResult{}, err
+	}
+	result, err := tx.ExecContext(ctx, tx.Rebind("DELETE FROM vacancies WHERE "+expired), before, before)
+	if err != nil {
+		return PurgeResult{}, err
+	}
+	vacancies, err := result.RowsAffected()
+	if err != nil {
+		return PurgeResult{}, err
+	}
+	purged.Vacancies = int(vacancies)
+
+	result, err = tx.ExecContext(ctx, tx.Rebind("DELETE FROM projects WHERE deleted_at < ?"), before)
+	if err != nil {
+		return PurgeResult{}, err
+	}
+	projects, err := result.RowsAffected()
+	if err != nil {
+		return PurgeResult{}, err
+	}
+	purged.Projects = int(projects)
+
+	return purged, tx.Commit()
+}
+
+var _ TrashRepository = (*SQLStore)(nil)
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+const userColumns = "u.id, u.email, u.name, u.password_hash, u.created_at"
+
+// isUniqueViolation распознает нарушение UNIQUE в ошибках обоих драйверов
+func isUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23505" // unique_violation
+	}
+	return false
+}
+
+// CreateUser реализует UserRepository
+func (s *SQLStore) CreateUser(ctx context.Context, user *db.User) error {
+	query := `
+		INSERT INTO users (email, name, password_hash, created_at)
+		VALUES (?, ?, ?, ?)
+		RETURNING id
+	`
+	err := s.get(ctx, &user.ID, query, user.Email, user.Name, user.PasswordHash, user.CreatedAt)
+	if isUniqueViolation(err) {
+		return ErrAlreadyExists
+	}
+	return err
+}
+
+// GetUser реализует UserRepository
+func (s *SQLStore) GetUser(ctx context.Context, id uint) (db.User, error) {
+	var user db.User
+	err := s.get(ctx, &user, "SELECT "+userColumns+" FROM users u WHERE u.id = ?", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return user, ErrNotFound
+	}
+	return user, err
+}
+
+// GetUserByEmail реализует UserRepository
+func (s *SQLStore) GetUserByEmail(ctx context.Context, email string) (db.User, error) {
+	var user db.User
+	err := s.get(ctx, &user, "SELECT "+userColumns+" FROM users u WHERE u.email = ?", email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return user, ErrNotFound
+	}
+	return user, err
+}
+
+const sessionColumns = "id, user_id, family_id, token_hash, created_at, expires_at, revoked_at"
+
+// CreateSession реализует UserRepository
+func (s *SQLStore) CreateSession(ctx context.Context, session *db.Session) error {
+	return insertSession(ctx, s.db, session)
+}
+
+// queryer - общее у *sqlx.DB и *sqlx.Tx, чтобы один запрос работал в транзакции и без нее
+type queryer interface {
+	sqlx.QueryerContext
+	Rebind(query string) string
+}
+
+// insertSession вставляет сессию через соединение или транзакцию
+func insertSession(ctx context.Context, q queryer, session *db.Session) error {
+	query := `
+		INSERT INTO sessions (user_id, family_id, token_hash, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?)
+		RETURNING id
+	`
+	return sqlx.GetContext(ctx, q, &session.ID, q.Rebind(query),
+		session.UserID, session.FamilyID, session.TokenHash, session.CreatedAt, session.ExpiresAt,
+	)
+}
+
+// GetSession реализует UserRepository
+func (s *SQLStore) GetSession(ctx context.Context, tokenHash string) (db.Session, error) {
+	var session db.Session
+	err := s.get(ctx, &session, "SELECT "+sessionColumns+" FROM sessions WHERE token_hash = ?", tokenHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return session, ErrNotFound
+	}
+	return session, err
+}
+
+// RotateSession реализует UserRepository
+func (s *SQLStore) RotateSession(ctx context.Context, oldID uint, next *db.Session, now time.Time) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Условие revoked_at IS NULL делает ротацию атомарной: из двух одновременных
+	// запросов с одним токеном новую сессию получит только первый
+	result, err := tx.ExecContext(ctx, tx.Rebind("UPDATE sessions SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL"), now, oldID)
+	if err != nil {
+		return err
+	}
+	if err := expectAffected(result); err != nil {
+		return err
+	}
+	if err := insertSession(ctx, tx, next); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RevokeSessionFamily реализует UserRepository
+func (s *SQLStore) RevokeSessionFamily(ctx context.Context, familyID string, now time.Time) error {
+	_, err := s.exec(ctx, "UPDATE sessions SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL", now, familyID)
+	return err
+}
+
+var _ UserRepository = (*SQLStore)(nil)
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+// store - все интерфейсы, которые реализуют и SQLStore, и MemoryStore
+type store interface {
+	ProjectRepository
+	VacancyRepository
+	TrashRepository
+	AuditRepository
+	ApplicationRepository
+	TeamRepository
+	MemberRepository
+	UserRepository
+}
+
+// postgresDSNEnv - строка подключения к пустой базе PostgreSQL для тестов SQLStore;
+// без нее SQLStore проверяется только на SQLite
+const postgresDSNEnv = "TEST_POSTGRES_DSN"
+
+// forEachStore запускает test на MemoryStore и на SQLStore поверх SQLite
+// (и PostgreSQL, если задан TEST_POSTGRES_DSN). Каждый подтест получает пустое хранилище.
+func forEachStore(t *testing.T, test func(t *testing.T, s store)) {
+	t.Run("memory", func(t *testing.T) {
+		test(t, NewMemoryStore())
+	})
+	t.Run(db.DriverSQLite, func(t *testing.T) {
+		test(t, newSQLStore(t, db.DriverSQLite))
+	})
+	t.Run(db.DriverPostgres, func(t *testing.T) {
+		test(t, newSQLStore(t, db.DriverPostgres))
+	})
+}
+
+// newSQLStore открывает пустую базу, применяет к ней миграции и возвращает SQLStore.
+// Для PostgreSQL схема пересоздается откатом всех миграций.
+func newSQLStore(t *testing.T, driver string) *SQLStore {
+	t.Helper()
+
+	dsn := "file:" + filepath.Join(t.TempDir(), "test.db") + "?_foreign_keys=on"
+	if driver == db.DriverPostgres {
+		if dsn = os.Getenv(postgresDSNEnv); dsn == "" {
+			t.Skipf("%s is not set", postgresDSNEnv)
+		}
+	}
+	conn, err := sqlx.Open(driver, dsn)
+	if err != nil {
+		t.Fatalf("open %s: %v", driver, err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if driver == db.DriverSQLite {
+		if _, err := conn.Exec("CREATE VIRTUAL TABLE fts5_probe USING fts5(body); DROP TABLE fts5_probe"); err != nil {
+			t.Skipf("SQLite without FTS5 (run tests with -tags sqlite_fts5): %v", err)
+		}
+	}
+
+	// Миграции работают с общим соединением пакета database
+	previous := db.DB
+	db.DB = conn
+	defer func() { db.DB = previous }()
+	if driver == db.DriverPostgres {
+		if _, err := db.MigrateDown(1 << 20); err != nil {
+			t.Fatalf("reset schema: %v", err)
+		}
+	}
+	if _, err := db.MigrateUp(); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return NewSQLStore(conn)
+}
+
+// daysFromToday - дата через days дней (в прошлом при отрицательном days)
+func daysFromToday(days int) db.Date {
+	return db.DateOf(time.Now().UTC().AddDate(0, 0, days))
+}
+
+// createUser добавляет пользователя и возвращает его ID
+func createUser(t *testing.T, s store, email string) uint {
+	t.Helper()
+	user := db.User{Email: email, Name: email, PasswordHash: "-", CreatedAt: time.Now().UTC()}
+	if err := s.CreateUser(context.Background(), &user); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	return user.ID
+}
+
+// createProject добавляет проект с дедлайном через месяц; change может поправить поля до сохранения
+func createProject(t *testing.T, s store, name string, change func(*db.Project)) db.Project {
+	t.Helper()
+	project := db.Project{Name: name, Deadline: daysFromToday(30), Experience: "1 year"}
+	if change != nil {
+		change(&project)
+	}
+	if err := s.CreateProject(context.Background(), &project); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	return project
+}
+
+// createVacancy добавляет открытую вакансию проекта с одним местом
+func createVacancy(t *testing.T, s store, projectID uint, name string) db.Vacancy {
+	t.Helper()
+	vacancy := db.Vacancy{ProjectID: projectID, Name: name, Field: "Design", Country: "DE", Openings: 1}
+	if err := s.CreateVacancy(context.Background(), &vacancy); err != nil {
+		t.Fatalf("CreateVacancy: %v", err)
+	}
+	return vacancy
+}
+
+// projectIDs - ID проектов по порядку
+func projectIDs(projects []db.Project) []uint {
+	ids := make([]uint, len(projects))
+	for i, p := range projects {
+		ids[i] = p.ID
+	}
+	return ids
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+func TestAddTeamMember(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s store) {
+		ctx := context.Background()
+		project := createProject(t, s, "Team", nil)
+
+		tests := []struct {
+			name string
+			// path - переходы вакансии перед наймом
+			path         []db.VacancyStatus
+			openings     int
+			wantErr      error
+			wantStatus   db.VacancyStatus
+			wantOpenings int
+		}{
+			{name: "open", openings: 2, wantStatus: db.VacancyOpen, wantOpenings: 1},
+			{name: "last opening", openings: 1, wantStatus: db.VacancyClosed, wantOpenings: 0},
+			{name: "paused", openings: 2, path: []db.VacancyStatus{db.VacancyPaused}, wantErr: ErrVacancyNotOpen},
+			{name: "closed by hand", openings: 2, path: []db.VacancyStatus{db.VacancyClosed}, wantErr: ErrVacancyNotOpen},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				vacancy := db.Vacancy{ProjectID: project.ID, Name: tt.name, Openings: tt.openings}
+				if err := s.CreateVacancy(ctx, &vacancy); err != nil {
+					t.Fatalf("CreateVacancy: %v", err)
+				}
+				for _, status := range tt.path {
+					if _, err := s.ChangeVacancyStatus(ctx, vacancy.ID, status, 0, db.Today()); err != nil {
+						t.Fatalf("ChangeVacancyStatus(%s): %v", status, err)
+					}
+				}
+
+				member := db.TeamMember{ProjectID: project.ID, VacancyID: &vacancy.ID, Name: "Jamie"}
+				err := s.AddTeamMember(ctx, &member)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("AddTeamMember error = %v, want %v", err, tt.wantErr)
+				}
+				if err != nil {
+					return
+				}
+				saved, err := s.GetVacancy(ctx, vacancy.ID)
+				if err != nil {
+					t.Fatalf("GetVacancy: %v", err)
+				}
+				if saved.Status != tt.wantStatus || saved.Openings != tt.wantOpenings {
+					t.Errorf("vacancy = %s with %d openings, want %s with %d", saved.Status, saved.Openings, tt.wantStatus, tt.wantOpenings)
+				}
+			})
+		}
+
+		// На вакансию без мест нанять нельзя, даже если ее статус позволял бы
+		full := createVacancy(t, s, project.ID, "Full")
+		for _, name := range []string{"Jamie", "Alex"} {
+			member := db.TeamMember{ProjectID: project.ID, VacancyID: &full.ID, Name: name}
+			err := s.AddTeamMember(ctx, &member)
+			if name == "Alex" && !errors.Is(err, ErrNoOpenings) {
+				t.Errorf("second hire error = %v, want %v", err, ErrNoOpenings)
+			}
+		}
+	})
+}
+
+func TestGetProjectStaffing(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s store) {
+		ctx := context.Background()
+		project := createProject(t, s, "Team", nil)
+
+		// Места считаются только у открытых вакансий
+		draft := db.Vacancy{ProjectID: project.ID, Name: "Draft", Openings: 2, Status: db.VacancyDraft}
+		if err := s.CreateVacancy(ctx, &draft); err != nil {
+			t.Fatalf("CreateVacancy: %v", err)
+		}
+		for _, status := range []db.VacancyStatus{db.VacancyOpen, db.VacancyPaused, db.VacancyClosed} {
+			vacancy := db.Vacancy{ProjectID: project.ID, Name: string(status), Openings: 2}
+			if err := s.CreateVacancy(ctx, &vacancy); err != nil {
+				t.Fatalf("CreateVacancy: %v", err)
+			}
+			if status != db.VacancyOpen {
+				if _, err := s.ChangeVacancyStatus(ctx, vacancy.ID, status, 0, db.Today()); err != nil {
+					t.Fatalf("ChangeVacancyStatus(%s): %v", status, err)
+				}
+			}
+		}
+		deleted := createVacancy(t, s, project.ID, "Deleted")
+		if err := s.DeleteVacancy(ctx, deleted.ID, 0); err != nil {
+			t.Fatalf("DeleteVacancy: %v", err)
+		}
+		if err := s.AddTeamMember(ctx, &db.TeamMember{ProjectID: project.ID, Name: "Jamie"}); err != nil {
+			t.Fatalf("AddTeamMember: %v", err)
+		}
+
+		staffing, err := s.GetProjectStaffing(ctx, project.ID)
+		if err != nil {
+			t.Fatalf("GetProjectStaffing: %v", err)
+		}
+		if want := (db.ProjectStaffing{Headcount: 1, OpenPositions: 2}); staffing != want {
+			t.Errorf("staffing = %+v, want %+v", staffing, want)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+func TestTrashRestore(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s store) {
+		ctx := context.Background()
+		project := createProject(t, s, "Trashed", nil)
+		withProject := createVacancy(t, s, project.ID, "Deleted with the project")
+		separately := createVacancy(t, s, project.ID, "Deleted separately")
+		if err := s.DeleteVacancy(ctx, separately.ID, 0); err != nil {
+			t.Fatalf("DeleteVacancy: %v", err)
+		}
+		if err := s.DeleteProject(ctx, project.ID, 0, DeletePolicyCascade); err != nil {
+			t.Fatalf("DeleteProject: %v", err)
+		}
+
+		// Вакансия, удаленная вместе с проектом, тоже лежит в корзине
+		if _, err := s.GetTrashItem(ctx, "vacancy", withProject.ID); err != nil {
+			t.Fatalf("GetTrashItem(vacancy): %v", err)
+		}
+		if _, err := s.RestoreVacancy(ctx, separately.ID); !errors.Is(err, ErrProjectDeleted) {
+			t.Fatalf("RestoreVacancy of a deleted project = %v, want %v", err, ErrProjectDeleted)
+		}
+
+		restored, err := s.RestoreProject(ctx, project.ID)
+		if err != nil {
+			t.Fatalf("RestoreProject: %v", err)
+		}
+		// Версию меняют и удаление, и восстановление
+		if restored.DeletedAt != nil || restored.Version != project.Version+2 {
+			t.Errorf("restored project deleted_at = %v, version = %d; want nil, %d", restored.DeletedAt, restored.Version, project.Version+2)
+		}
+		if _, err := s.RestoreProject(ctx, project.ID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("second RestoreProject = %v, want %v", err, ErrNotFound)
+		}
+
+		tests := []struct {
+			name      string
+			vacancyID uint
+			wantFound bool
+		}{
+			{"deleted with the project", withProject.ID, true},
+			{"deleted separately", separately.ID, false},
+		}
+		for _, tt := range tests {
+			if _, err := s.GetVacancy(ctx, tt.vacancyID); (err == nil) != tt.wantFound {
+				t.Errorf("%s: GetVacancy error = %v, want found = %v", tt.name, err, tt.wantFound)
+			}
+		}
+
+		// Вакансию, удаленную отдельно, можно вернуть, когда проект уже восстановлен
+		if _, err := s.RestoreVacancy(ctx, separately.ID); err != nil {
+			t.Errorf("RestoreVacancy: %v", err)
+		}
+	})
+}
+
+func TestPurgeTrash(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s store) {
+		ctx := context.Background()
+		kept := createProject(t, s, "Kept", nil)
+		deletedVacancy := createVacancy(t, s, kept.ID, "Deleted vacancy")
+		activeVacancy := createVacancy(t, s, kept.ID, "Active vacancy")
+		purged := createProject(t, s, "Purged", nil)
+		purgedVacancy := createVacancy(t, s, purged.ID, "Purged with the project")
+		application := db.Application{VacancyID: purgedVacancy.ID, Name: "Jamie", Email: "jamie@example.com"}
+		if err := s.CreateApplication(ctx, &application); err != nil {
+			t.Fatalf("CreateApplication: %v", err)
+		}
+		if err := s.DeleteVacancy(ctx, deletedVacancy.ID, 0); err != nil {
+			t.Fatalf("DeleteVacancy: %v", err)
+		}
+		if err := s.DeleteProject(ctx, purged.ID, 0, DeletePolicyCascade); err != nil {
+			t.Fatalf("DeleteProject: %v", err)
+		}
+
+		// Все удалено позже этой отметки: очищать нечего
+		result, err := s.PurgeTrash(ctx, time.Now().UTC().Add(-time.Hour))
+		if err != nil || result != (PurgeResult{}) {
+			t.Fatalf("PurgeTrash(an hour ago) = %+v, %v; want nothing purged", result, err)
+		}
+
+		result, err = s.PurgeTrash(ctx, time.Now().UTC().Add(time.Minute))
+		if err != nil {
+			t.Fatalf("PurgeTrash: %v", err)
+		}
+		if want := (PurgeResult{Projects: 1, Vacancies: 2}); result != want {
+			t.Errorf("PurgeTrash = %+v, want %+v", result, want)
+		}
+
+		tests := []struct {
+			name     string
+			itemType string
+			id       uint
+		}{
+			{"project", "project", purged.ID},
+			{"vacancy of the project", "vacancy", purgedVacancy.ID},
+			{"vacancy", "vacancy", deletedVacancy.ID},
+		}
+		for _, tt := range tests {
+			if _, err := s.GetTrashItem(ctx, tt.itemType, tt.id); !errors.Is(err, ErrNotFound) {
+				t.Errorf("%s: GetTrashItem after purge = %v, want %v", tt.name, err, ErrNotFound)
+			}
+		}
+		if _, err := s.GetApplication(ctx, application.ID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetApplication after purge = %v, want %v", err, ErrNotFound)
+		}
+		if _, err := s.GetVacancy(ctx, activeVacancy.ID); err != nil {
+			t.Errorf("GetVacancy of an active vacancy: %v", err)
+		}
+	})
+}
+
+// Вакансии проекта в корзине не ищутся и не истекают, даже если сами не удалены
+func TestDeletedProjectHidesVacancies(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s store) {
+		ctx := context.Background()
+		project := createProject(t, s, "Trashed", func(p *db.Project) { p.Deadline = daysFromToday(-1) })
+		vacancy := createVacancy(t, s, project.ID, "Left behind")
+		trashProjectOnly(t, s, project.ID)
+
+		if _, total, err := s.SearchVacancies(ctx, VacancyFilter{Page: Page{Limit: 10}}); err != nil || total != 0 {
+			t.Errorf("SearchVacancies = %d vacancies, %v; want 0", total, err)
+		}
+		if expired, err := s.ExpireVacancies(ctx, db.Today()); err != nil || expired != 0 {
+			t.Errorf("ExpireVacancies = %d, %v; want 0", expired, err)
+		}
+		if saved, err := s.GetVacancy(ctx, vacancy.ID); err != nil || saved.Status != db.VacancyOpen {
+			t.Errorf("GetVacancy = %s, %v; want %s", saved.Status, err, db.VacancyOpen)
+		}
+	})
+}
+
+// trashProjectOnly помечает проект удаленным, не трогая его вакансии, - так
+// выглядит вакансия, созданная одновременно с каскадным удалением проекта
+func trashProjectOnly(t *testing.T, s store, id uint) {
+	t.Helper()
+	now := time.Now().UTC()
+	switch s := s.(type) {
+	case *MemoryStore:
+		project := s.projects[id]
+		project.DeletedAt = &now
+		s.projects[id] = project
+	case *SQLStore:
+		if _, err := s.db.Exec(s.db.Rebind("UPDATE projects SET deleted_at = ? WHERE id = ?"), now, id); err != nil {
+			t.Fatalf("trash project: %v", err)
+		}
+	default:
+		t.Fatalf("unexpected store %T", s)
+	}
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	db "github.com/troodinc/trood-front-hackathon/database"
+)
+
+func TestChangeVacancyStatus(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s store) {
+		ctx := context.Background()
+		project := createProject(t, s, "Active", nil)
+		overdue := createProject(t, s, "Overdue", func(p *db.Project) { p.Deadline = daysFromToday(-1) })
+
+		tests := []struct {
+			name    string
+			project uint
+			// path - переходы, которые выполняются до проверяемого
+			path    []db.VacancyStatus
+			to      db.VacancyStatus
+			stale   bool // передать устаревшую версию
+			hire    bool // занять единственное место перед переходом
+			wantErr error
+		}{
+			{name: "open to paused", project: project.ID, to: db.VacancyPaused},
+			{name: "paused to open", project: project.ID, path: []db.VacancyStatus{db.VacancyPaused}, to: db.VacancyOpen},
+			{name: "closed to open", project: project.ID, path: []db.VacancyStatus{db.VacancyClosed}, to: db.VacancyOpen},
+			{name: "open to open", project: project.ID, to: db.VacancyOpen, wantErr: &InvalidVacancyTransitionError{}},
+			{name: "closed to paused", project: project.ID, path: []db.VacancyStatus{db.VacancyClosed}, to: db.VacancyPaused, wantErr: &InvalidVacancyTransitionError{}},
+			{name: "stale version", project: project.ID, path: []db.VacancyStatus{db.VacancyPaused}, to: db.VacancyClosed, stale: true, wantErr: ErrVersionMismatch},
+			{name: "open without openings", project: project.ID, hire: true, to: db.VacancyOpen, wantErr: ErrNoOpenings},
+			{name: "open after the deadline", project: overdue.ID, path: []db.VacancyStatus{db.VacancyPaused}, to: db.VacancyOpen, wantErr: ErrDeadlinePassed},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				vacancy := createVacancy(t, s, tt.project, tt.name)
+				for _, status := range tt.path {
+					var err error
+					if vacancy, err = s.ChangeVacancyStatus(ctx, vacancy.ID, status, 0, db.Today()); err != nil {
+						t.Fatalf("ChangeVacancyStatus(%s): %v", status, err)
+					}
+				}
+				if tt.hire {
+					member := db.TeamMember{ProjectID: tt.project, VacancyID: &vacancy.ID, Name: "Jamie"}
+					if err := s.AddTeamMember(ctx, &member); err != nil {
+						t.Fatalf("AddTeamMember: %v", err)
+					}
+					vacancy.Version++
+				}
+				version := vacancy.Version
+				if tt.stale {
+					version--
+				}
+
+				changed, err := s.ChangeVacancyStatus(ctx, vacancy.ID, tt.to, version, db.Today())
+				switch target := tt.wantErr.(type) {
+				case nil:
+					if err != nil {
+						t.Fatalf("ChangeVacancyStatus: %v", err)
+					}
+					if changed.Status != tt.to || changed.Version != vacancy.Version+1 {
+						t.Errorf("vacancy = %s v%d, want %s v%d", changed.Status, changed.Version, tt.to, vacancy.Version+1)
+					}
+				case *InvalidVacancyTransitionError:
+					if !errors.As(err, &target) || target.From != vacancy.Status || target.To != tt.to {
+						t.Errorf("error = %v, want transition error from %s to %s", err, vacancy.Status, tt.to)
+					}
+				default:
+					if !errors.Is(err, tt.wantErr) {
+						t.Errorf("error = %v, want %v", err, tt.wantErr)
+					}
+				}
+			})
+		}
+	})
+}
+
+func TestRemoveTeamMemberReopensVacancy(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s store) {
+		ctx := context.Background()
+		project := createProject(t, s, "Team", nil)
+
+		tests := []struct {
+			name        string
+			openings    int
+			closeByHand bool // закрыть вакансию вручную, пока место еще свободно
+			wantStatus  db.VacancyStatus
+		}{
+			{name: "closed by the last hire", openings: 1, wantStatus: db.VacancyOpen},
+			{name: "closed by hand", openings: 2, closeByHand: true, wantStatus: db.VacancyClosed},
+			{name: "still open", openings: 2, wantStatus: db.VacancyOpen},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				vacancy := db.Vacancy{ProjectID: project.ID, Name: tt.name, Openings: tt.openings}
+				if err := s.CreateVacancy(ctx, &vacancy); err != nil {
+					t.Fatalf("CreateVacancy: %v", err)
+				}
+				member := db.TeamMember{ProjectID: project.ID, VacancyID: &vacancy.ID, Name: "Jamie"}
+				if err := s.AddTeamMember(ctx, &member); err != nil {
+					t.Fatalf("AddTeamMember: %v", err)
+				}
+				if tt.closeByHand {
+					if _, err := s.ChangeVacancyStatus(ctx, vacancy.ID, db.VacancyClosed, 0, db.Today()); err != nil {
+						t.Fatalf("ChangeVacancyStatus: %v", err)
+					}
+				}
+
+				if err := s.RemoveTeamMember(ctx, project.ID, member.ID); err != nil {
+					t.Fatalf("RemoveTeamMember: %v", err)
+				}
+				saved, err := s.GetVacancy(ctx, vacancy.ID)
+				if err != nil {
+					t.Fatalf("GetVacancy: %v", err)
+				}
+				if saved.Status != tt.wantStatus || saved.Openings != tt.openings {
+					t.Errorf("vacancy = %s with %d openings, want %s with %d", saved.Status, saved.Openings, tt.wantStatus, tt.openings)
+				}
+			})
+		}
+	})
+}
+
+func TestUpdateVacancyKeepsStatus(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s store) {
+		ctx := context.Background()
+		project := createProject(t, s, "Team", nil)
+
+		tests := []struct {
+			name string
+			// hire - закрыть вакансию последним наймом, иначе закрыть вручную
+			hire     bool
+			openings int
+		}{
+			{name: "closed by hand, description only", openings: 0},
+			{name: "closed by hand, new openings", openings: 3},
+			{name: "closed by the last hire, new openings", hire: true, openings: 3},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				vacancy := createVacancy(t, s, project.ID, tt.name)
+				if tt.hire {
+					member := db.TeamMember{ProjectID: project.ID, VacancyID: &vacancy.ID, Name: "Jamie"}
+					if err := s.AddTeamMember(ctx, &member); err != nil {
+						t.Fatalf("AddTeamMember: %v", err)
+					}
+				} else if _, err := s.ChangeVacancyStatus(ctx, vacancy.ID, db.VacancyClosed, 0, db.Today()); err != nil {
+					t.Fatalf("ChangeVacancyStatus: %v", err)
+				}
+				closed, err := s.GetVacancy(ctx, vacancy.ID)
+				if err != nil {
+					t.Fatalf("GetVacancy: %v", err)
+				}
+
+				update := closed
+				update.Description = "Updated"
+				update.Openings = tt.openings
+				update.Version = 0
+				if err := s.UpdateVacancy(ctx, &update); err != nil {
+					t.Fatalf("UpdateVacancy: %v", err)
+				}
+				saved, err := s.GetVacancy(ctx, vacancy.ID)
+				if err != nil {
+					t.Fatalf("GetVacancy: %v", err)
+				}
+				wantOpenings := closed.Openings
+				if tt.openings > 0 {
+					wantOpenings = tt.openings
+				}
+				if saved.Status != db.VacancyClosed || saved.ClosedAt == nil || saved.Openings != wantOpenings {
+					t.Errorf("vacancy = %s (closed_at %v) with %d openings, want closed with %d", saved.Status, saved.ClosedAt, saved.Openings, wantOpenings)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/troodinc/trood-front-hackathon/config"
+	"github.com/troodinc/trood-front-hackathon/handlers"
+)
+
+// serve запускает HTTP-сервер и блокируется до SIGINT/SIGTERM или ошибки запуска.
+// При остановке сервер сначала отмечается как неготовый (/readyz -> 503),
+// затем ждет ShutdownDelay, чтобы балансировщик успел это заметить, и
+// дожидается завершения активных запросов не дольше ShutdownTimeout.
+func serve(handler http.Handler, health *handlers.HealthHandler, cfg config.ServerConfig) error {
+	srv := &http.Server{
+		Addr:              cfg.Addr(),
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
+
+	select {
+	case err := <-serverErr:
+		return err
+	case <-ctx.Done():
+	}
+	// Повторный сигнал завершит процесс сразу, без ожидания
+	stop()
+
+	log.Printf("Shutdown signal received, draining connections (timeout %s)", cfg.ShutdownTimeout)
+	health.SetShuttingDown()
+	if cfg.ShutdownDelay > 0 {
+		time.Sleep(cfg.ShutdownDelay)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		// Не дождались активных запросов - закрываем соединения принудительно
+		srv.Close()
+		return err
+	}
+	log.Println("Server stopped")
+	return nil
+}
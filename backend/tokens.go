@@ -0,0 +1,34 @@
+package main
+
+import (
+	"log"
+
+	"github.com/troodinc/trood-front-hackathon/auth"
+	"github.com/troodinc/trood-front-hackathon/config"
+)
+
+// newTokenService собирает выпуск и проверку JWT из конфигурации.
+// Без auth.jwt_secret генерируется случайный секрет: удобно локально,
+// но после перезапуска все выданные access-токены станут недействительными.
+func newTokenService(cfg config.AuthConfig) *auth.TokenService {
+	secret := cfg.JWTSecret
+	if secret == "" {
+		generated, _, err := auth.NewToken()
+		if err != nil {
+			log.Fatalf("Failed to generate JWT secret: %v", err)
+		}
+		secret = generated
+		log.Println("Warning: JWT_SECRET is not set, using a random secret; access tokens will not survive a restart")
+	}
+
+	keys, err := auth.NewHMACKeySet(secret, cfg.JWTPreviousSecrets...)
+	if err != nil {
+		log.Fatalf("Invalid JWT secret: %v", err)
+	}
+	return &auth.TokenService{
+		Keys:      keys,
+		Issuer:    cfg.Issuer,
+		Audience:  cfg.Audience,
+		AccessTTL: cfg.AccessTTL,
+	}
+}
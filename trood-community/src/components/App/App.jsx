@@ -1,6 +1,7 @@
 import React from 'react';
 import { Route, Routes } from 'react-router-dom';
 import Header from '../Header/Header';
+import LoginPage from '../LoginPage/LoginPage';
 import MainContent from '../MainContent/MainContent';
 import ProjectCreatePage from '../ProjectCreatePage/ProjectCreatePage';
 import ProjectDetailPage from '../ProjectDetailPage/ProjectDetailPage';
@@ -19,6 +20,7 @@ function App() {
         <main className={styles.mainContentArea}>
           <Routes>
             <Route path="/" element={<MainContent />} />
+            <Route path="/login" element={<LoginPage />} />
             <Route path="/projects/new" element={<ProjectCreatePage />} />
             <Route path="/projects/:projectId/edit" element={<ProjectEditPage />} />
             <Route path="/projects/:projectId/vacancies/new" element={<VacancyCreatePage />} />
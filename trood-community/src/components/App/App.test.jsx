@@ -8,6 +8,7 @@ import App from './App';
 vi.mock('../Header/Header', () => ({ default: () => <header data-testid="header">Header Mock</header> }));
 vi.mock('../Sidebar/Sidebar', () => ({ default: () => <aside data-testid="sidebar">Sidebar Mock</aside> }));
 vi.mock('../MainContent/MainContent', () => ({ default: () => <div data-testid="main-content">Main Content Mock</div> }));
+vi.mock('../LoginPage/LoginPage', () => ({ default: () => <div data-testid="login-page">Login Mock</div> }));
 vi.mock('../ProjectCreatePage/ProjectCreatePage', () => ({ default: () => <div data-testid="project-create-page">Project Create Mock</div> }));
 vi.mock('../ProjectDetailPage/ProjectDetailPage', () => ({ default: () => <div data-testid="project-detail-page">Project Detail Mock</div> }));
 vi.mock('../ProjectEditPage/ProjectEditPage', () => ({ default: () => <div data-testid="project-edit-page">Project Edit Mock</div> }));
@@ -36,6 +37,11 @@ describe('App Component Routing', () => {
 		expect(screen.getByTestId('main-content')).toBeInTheDocument();
 	});
 
+	it('should render LoginPage on "/login" route', () => {
+		renderApp('/login');
+		expect(screen.getByTestId('login-page')).toBeInTheDocument();
+	});
+
 	it('should render ProjectCreatePage on "/projects/new" route', () => {
 		renderApp('/projects/new');
 		expect(screen.getByTestId('project-create-page')).toBeInTheDocument();
@@ -1,9 +1,17 @@
-import React from 'react';
+import React, { useState } from 'react';
+import { getCurrentUser, logout } from '../../services/api';
 import FiBell from './FiBell';
 import FiMessageSquare from './FiMessageSquare';
 import styles from './Header.module.css';
 
 const Header = () => {
+  const [user, setUser] = useState(getCurrentUser);
+
+  const handleLogout = async () => {
+    await logout();
+    setUser(null);
+  };
+
   return (
     <header className={styles.header}>
       <div className={styles.logo}>TROOD COMMUNITY</div>
@@ -11,10 +19,17 @@ const Header = () => {
         <FiMessageSquare className={styles.icon} />
         <FiBell className={styles.icon} />
         <div className={styles.avatar}></div> 
-        <span className={styles.userName}>Alex Smith</span>
+        {user ? (
+          <>
+            <span className={styles.userName}>{user.name}</span>
+            <button type="button" className={styles.authLink} onClick={handleLogout}>Log out</button>
+          </>
+        ) : (
+          <a href="/login" className={styles.authLink}>Log in</a>
+        )}
       </div>
     </header>
   );
 };
 
-export default Header;
\ No newline at end of file
+export default Header;
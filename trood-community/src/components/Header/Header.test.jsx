@@ -1,16 +1,26 @@
-
-import { render, screen } from '@testing-library/react';
+import { render, screen, waitFor } from '@testing-library/react';
+import userEvent from '@testing-library/user-event';
 import React from 'react';
-import { describe, expect, it, vi } from 'vitest';
+import { beforeEach, describe, expect, it, vi } from 'vitest';
+import { getCurrentUser, logout } from '../../services/api';
 import Header from './Header';
 
 vi.mock('./FiBell', () => ({ default: () => <svg data-testid="bell-icon" /> }));
 vi.mock('./FiMessageSquare', () => ({ default: () => <svg data-testid="message-icon" /> }));
+vi.mock('../../services/api', () => ({
+  getCurrentUser: vi.fn(),
+  logout: vi.fn(),
+}));
 
 
 describe('Header Component', () => {
 
-  it('should render logo, icons, and user name', () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+  });
+
+  it('should render logo, icons, and the logged in user name', () => {
+    getCurrentUser.mockReturnValue({ id: 1, name: 'Alex Smith' });
     render(<Header />);
 
     expect(screen.getByText(/TROOD COMMUNITY/i)).toBeInTheDocument();
@@ -24,4 +34,24 @@ describe('Header Component', () => {
     expect(avatar).toBeInTheDocument();
   });
 
-});
\ No newline at end of file
+  it('should link to the login page when nobody is logged in', () => {
+    getCurrentUser.mockReturnValue(null);
+    render(<Header />);
+
+    expect(screen.getByRole('link', { name: /Log in/i })).toHaveAttribute('href', '/login');
+  });
+
+  it('should log out and show the login link again', async () => {
+    const user = userEvent.setup();
+    getCurrentUser.mockReturnValue({ id: 1, name: 'Alex Smith' });
+    logout.mockResolvedValue();
+    render(<Header />);
+
+    await user.click(screen.getByRole('button', { name: /Log out/i }));
+
+    await waitFor(() => expect(logout).toHaveBeenCalledTimes(1));
+    expect(await screen.findByRole('link', { name: /Log in/i })).toBeInTheDocument();
+    expect(screen.queryByText(/Alex Smith/i)).not.toBeInTheDocument();
+  });
+
+});
@@ -0,0 +1,77 @@
+import React, { useState } from 'react';
+import { useNavigate } from 'react-router-dom';
+import { login } from '../../services/api';
+import Button from '../Button/Button';
+import styles from './LoginPage.module.css';
+
+const LoginPage = () => {
+  const navigate = useNavigate();
+
+  const [formData, setFormData] = useState({ email: '', password: '' });
+  const [isLoading, setIsLoading] = useState(false);
+  const [error, setError] = useState(null);
+
+  const handleChange = (event) => {
+    const { name, value } = event.target;
+    setFormData(prevData => ({
+      ...prevData,
+      [name]: value,
+    }));
+  };
+
+  const handleSubmit = async (event) => {
+    event.preventDefault();
+    setIsLoading(true);
+    setError(null);
+
+    try {
+      await login(formData.email, formData.password);
+      navigate('/');
+    } catch (err) {
+      console.error('Ошибка входа:', err);
+      setError(err.message || 'Не удалось войти. Попробуйте снова.');
+    } finally {
+      setIsLoading(false);
+    }
+  };
+
+  return (
+    <div className={styles.mainContent}>
+      <h2 className={styles.title}>Log in</h2>
+      <form onSubmit={handleSubmit}>
+        <div className={styles.formGrid}>
+          <div>
+            <label htmlFor="loginEmail" className={styles.formLabel}>Email</label>
+            <input
+              type="email"
+              id="loginEmail"
+              name="email"
+              className={styles.formInput}
+              value={formData.email}
+              onChange={handleChange}
+              required
+            />
+          </div>
+          <div>
+            <label htmlFor="loginPassword" className={styles.formLabel}>Password</label>
+            <input
+              type="password"
+              id="loginPassword"
+              name="password"
+              className={styles.formInput}
+              value={formData.password}
+              onChange={handleChange}
+              required
+            />
+          </div>
+        </div>
+        {error && <p className={styles.errorMessage}>{error}</p>}
+        <Button type="submit" disabled={isLoading}>
+          {isLoading ? 'Logging in...' : 'Log in'}
+        </Button>
+      </form>
+    </div>
+  );
+};
+
+export default LoginPage;
@@ -0,0 +1,67 @@
+import { render, screen, waitFor } from '@testing-library/react';
+import userEvent from '@testing-library/user-event';
+import React from 'react';
+import { MemoryRouter } from 'react-router-dom';
+import { beforeEach, describe, expect, it, vi } from 'vitest';
+import { login } from '../../services/api';
+import LoginPage from './LoginPage';
+
+vi.mock('../../services/api', () => ({
+  login: vi.fn(),
+}));
+
+const mockNavigate = vi.fn();
+vi.mock('react-router-dom', async (importOriginal) => {
+  const actual = await importOriginal();
+  return { ...actual, useNavigate: () => mockNavigate };
+});
+
+const renderWithRouter = (ui) => {
+  return render(<MemoryRouter>{ui}</MemoryRouter>);
+};
+
+
+describe('LoginPage Component', () => {
+
+  beforeEach(() => {
+    vi.clearAllMocks();
+  });
+
+  it('should render the form correctly', () => {
+    renderWithRouter(<LoginPage />);
+
+    expect(screen.getByRole('heading', { name: /Log in/i })).toBeInTheDocument();
+    expect(screen.getByLabelText(/Email/i)).toBeInTheDocument();
+    expect(screen.getByLabelText(/Password/i)).toBeInTheDocument();
+    expect(screen.getByRole('button', { name: /Log in/i })).toBeInTheDocument();
+  });
+
+  it('should log in and navigate home on success', async () => {
+    const user = userEvent.setup();
+    login.mockResolvedValue({ id: 1, name: 'Alex Smith' });
+    renderWithRouter(<LoginPage />);
+
+    await user.type(screen.getByLabelText(/Email/i), 'alex.smith@example.com');
+    await user.type(screen.getByLabelText(/Password/i), 'secret123');
+    await user.click(screen.getByRole('button', { name: /Log in/i }));
+
+    await waitFor(() => {
+      expect(login).toHaveBeenCalledWith('alex.smith@example.com', 'secret123');
+      expect(mockNavigate).toHaveBeenCalledWith('/');
+    });
+  });
+
+  it('should show the error message and stay on the page on failure', async () => {
+    const user = userEvent.setup();
+    login.mockRejectedValue(new Error('Invalid email or password'));
+    renderWithRouter(<LoginPage />);
+
+    await user.type(screen.getByLabelText(/Email/i), 'alex.smith@example.com');
+    await user.type(screen.getByLabelText(/Password/i), 'wrong');
+    await user.click(screen.getByRole('button', { name: /Log in/i }));
+
+    expect(await screen.findByText(/Invalid email or password/i)).toBeInTheDocument();
+    expect(mockNavigate).not.toHaveBeenCalled();
+  });
+
+});
@@ -8,12 +8,83 @@ const BASE_URL =
     : 'http://65.108.87.81:8080';
 
 
-async function request(endpoint, options = {}) {
+// Пара токенов из POST /auth/login и POST /auth/refresh хранится в localStorage
+const ACCESS_TOKEN_KEY = 'accessToken';
+const REFRESH_TOKEN_KEY = 'refreshToken';
+const USER_KEY = 'user';
+
+const saveSession = (auth) => {
+  localStorage.setItem(ACCESS_TOKEN_KEY, auth.access_token);
+  localStorage.setItem(REFRESH_TOKEN_KEY, auth.refresh_token);
+  localStorage.setItem(USER_KEY, JSON.stringify(auth.user));
+};
+
+const clearSession = () => {
+  localStorage.removeItem(ACCESS_TOKEN_KEY);
+  localStorage.removeItem(REFRESH_TOKEN_KEY);
+  localStorage.removeItem(USER_KEY);
+};
+
+// Текущий пользователь или null, если вход не выполнен
+export const getCurrentUser = () => {
+  try {
+    return JSON.parse(localStorage.getItem(USER_KEY));
+  } catch {
+    return null;
+  }
+};
+
+// Одновременные запросы с истекшим токеном ждут одного и того же обновления:
+// refresh-токен одноразовый, второй POST /auth/refresh с ним отозвал бы всю сессию
+let refreshPromise = null;
+
+const refreshSession = () => {
+  const refreshToken = localStorage.getItem(REFRESH_TOKEN_KEY);
+  if (!refreshToken) {
+    return Promise.resolve(false);
+  }
+  if (!refreshPromise) {
+    refreshPromise = fetch(`${BASE_URL}/auth/refresh`, {
+      method: 'POST',
+      headers: { 'Accept': 'application/json', 'Content-Type': 'application/json' },
+      body: JSON.stringify({ refresh_token: refreshToken }),
+    })
+      .then(async (response) => {
+        if (!response.ok) {
+          clearSession();
+          return false;
+        }
+        saveSession(await response.json());
+        return true;
+      })
+      .catch(() => false)
+      .finally(() => {
+        refreshPromise = null;
+      });
+  }
+  return refreshPromise;
+};
+
+// ETag последнего ответа по адресу записи (например, /projects/3). PUT, PATCH и DELETE
+// отправляют его в If-Match, чтобы не перезаписать чужие изменения: если запись
+// изменилась после загрузки, сервер ответит 412
+const etags = new Map();
+
+const CONDITIONAL_METHODS = ['PUT', 'PATCH', 'DELETE'];
+
+async function request(endpoint, options = {}, canRefresh = true) {
   const url = `${BASE_URL}${endpoint}`;
+  const method = options.method || 'GET';
+
+  // Изменяющие запросы требуют access-токен из POST /auth/login
+  const accessToken = localStorage.getItem(ACCESS_TOKEN_KEY);
+  const etag = CONDITIONAL_METHODS.includes(method) ? etags.get(endpoint) : undefined;
 
   const headers = {
     'Accept': 'application/json',
     ...(options.body && { 'Content-Type': 'application/json' }),
+    ...(accessToken && { 'Authorization': `Bearer ${accessToken}` }),
+    ...(etag && { 'If-Match': etag }),
     ...options.headers,
   };
 
@@ -22,7 +93,7 @@ async function request(endpoint, options = {}) {
     headers,
   };
 
-  console.log(`API Request: ${config.method || 'GET'} ${url}`);
+  console.log(`API Request: ${method} ${url}`);
   if (config.body) {
     console.log('Request Body:', config.body);
   }
@@ -30,6 +101,20 @@ async function request(endpoint, options = {}) {
   try {
     const response = await fetch(url, config);
 
+    // Access-токен истек: обновляем пару токенов и повторяем запрос один раз
+    if (response.status === 401 && accessToken && canRefresh && await refreshSession()) {
+      return request(endpoint, options, false);
+    }
+
+    if (response.ok) {
+      const responseETag = response.headers.get('ETag');
+      if (method === 'DELETE') {
+        etags.delete(endpoint);
+      } else if (responseETag) {
+        etags.set(endpoint, responseETag);
+      }
+    }
+
     if (response.status === 204) {
       console.log(`API Response ${response.status}: No Content`);
       return null;
@@ -49,7 +134,9 @@ async function request(endpoint, options = {}) {
     }
 
     if (!response.ok) {
-      const errorMessage = data?.message || data?.detail || `API Error: ${response.status} ${response.statusText}`;
+      const errorMessage = response.status === 401 && endpoint !== '/auth/login'
+        ? 'Войдите в аккаунт, чтобы вносить изменения'
+        : data?.message || data?.detail || data?.title || `API Error: ${response.status} ${response.statusText}`;
       console.error(`API Error ${response.status}: ${errorMessage}`, data);
       throw new Error(errorMessage);
     }
@@ -62,8 +149,40 @@ async function request(endpoint, options = {}) {
   }
 }
 
+export const login = async (email, password) => {
+  const auth = await request('/auth/login', {
+    method: 'POST',
+    body: JSON.stringify({ email, password }),
+  }, false);
+  saveSession(auth);
+  return auth.user;
+};
+
+// logout отзывает refresh-токен на сервере; локальная сессия очищается в любом случае
+export const logout = async () => {
+  const refreshToken = localStorage.getItem(REFRESH_TOKEN_KEY);
+  clearSession();
+  if (refreshToken) {
+    await request('/auth/logout', {
+      method: 'POST',
+      body: JSON.stringify({ refresh_token: refreshToken }),
+    }, false).catch(() => null);
+  }
+};
 
-export const getProjects = () => request('/projects');
+
+// GET /projects возвращает страницу в конверте { items, total, next, ... };
+// проходим по ссылкам next, пока не соберем все страницы
+export const getProjects = async () => {
+  const projects = [];
+  let endpoint = '/projects?limit=100';
+  while (endpoint) {
+    const page = await request(endpoint);
+    projects.push(...page.items);
+    endpoint = page.next;
+  }
+  return projects;
+};
 
 export const createProject = (projectData) => request('/projects', {
   method: 'POST',